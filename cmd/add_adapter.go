@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/padiazg/hexago/internal/analyzer"
@@ -18,6 +19,7 @@ var (
 	adapterPrimaryEntity string
 	fromPort             string
 	inferTests           bool
+	adapterDialect       string
 )
 
 // addAdapterCmd represents the add adapter command
@@ -45,10 +47,19 @@ Types:
   http   - HTTP handler
   grpc   - gRPC handler
   queue  - Message queue consumer
+  cli    - Cobra subcommand (register it in Commands in cli.go)
+
+With --entity, a grpc adapter is derived from the code: a .proto from the
+entity fields and the service port methods, a server implementation calling
+the core service, and its registration on the gRPC server in pkg/grpcserver,
+the pkg/server.Server implementation over grpc.Server (as pkg/httpserver is
+for HTTP). Without the entity or the service port, the command fails.
 
 Example:
   hexago add adapter primary http UserHandler
-  hexago add adapter primary grpc OrderService`,
+  hexago add adapter primary grpc OrderService
+  hexago add adapter primary grpc OrderService --entity Order
+  hexago add adapter primary cli ExportUsers`,
 	Args: cobra.ExactArgs(2),
 	RunE: runAddAdapterPrimary,
 }
@@ -64,8 +75,16 @@ Types:
   external  - External service client
   cache     - Cache adapter
 
+With --entity, a database adapter is derived from the entity fields: SQL for
+Create, FindByID, Update, Delete and List with column mapping, "no rows"
+translated to domain.ErrNotFound, and a migration creating the table.
+The SQL dialect and the driver style (database/sql, pgx or sqlx) come from
+database.dialect and database.driver in .hexago.yaml; --dialect overrides
+the dialect.
+
 Example:
   hexago add adapter secondary database UserRepository
+  hexago add adapter secondary database UserRepository --entity User --dialect postgres
   hexago add adapter secondary external EmailService`,
 	Args: cobra.ExactArgs(2),
 	RunE: runAddAdapterSecondary,
@@ -78,11 +97,12 @@ func init() {
 
 	// Flags
 	addAdapterPrimaryCmd.Flags().StringVarP(&adapterPort, "port", "p", "", "Port interface name (if using explicit ports)")
-	addAdapterPrimaryCmd.Flags().StringVarP(&adapterPrimaryEntity, "entity", "e", "", "Domain entity this handler serves (PascalCase); generates sub-package with config+handlers files")
+	addAdapterPrimaryCmd.Flags().StringVarP(&adapterPrimaryEntity, "entity", "e", "", "Domain entity this handler serves (PascalCase); generates a sub-package (http: config+handlers, grpc: .proto+server)")
 	addAdapterSecondaryCmd.Flags().StringVarP(&adapterPort, "port", "p", "", "Port interface name (if using explicit ports)")
 	addAdapterSecondaryCmd.Flags().StringVarP(&adapterEntity, "entity", "e", "", "Domain entity this adapter implements (PascalCase); determines sub-package for database adapters")
 	addAdapterSecondaryCmd.Flags().StringVarP(&fromPort, "from-port", "", "", "Port interface name to infer method signatures from")
 	addAdapterSecondaryCmd.Flags().BoolVarP(&inferTests, "infer-tests", "", false, "Generate tests with method signatures from port")
+	addAdapterSecondaryCmd.Flags().StringVar(&adapterDialect, "dialect", "", "SQL dialect of database adapters generated with --entity (default: database.dialect from .hexago.yaml)")
 }
 
 func runAddAdapterPrimary(cmd *cobra.Command, args []string) error {
@@ -97,25 +117,104 @@ func runAddAdapterPrimary(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
+	config.DryRun = dryRun
 
-	fmt.Printf("📦 Adding primary adapter: %s (%s)\n", adapterName, adapterType)
-	fmt.Printf("   Project: %s\n", config.ProjectName)
-	fmt.Printf("   Adapter dir: %s\n\n", config.AdapterInboundDir())
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "📦 Adding primary adapter: %s (%s)\n", adapterName, adapterType)
+	fmt.Fprintf(out, "   Project: %s\n", config.ProjectName)
+	fmt.Fprintf(out, "   Adapter dir: %s\n\n", config.AdapterInboundDir())
 
 	gen := generator.NewAdapterGenerator(config)
+
+	// gRPC + entity → .proto, server and registration derived from the code
+	if adapterType == "grpc" && adapterPrimaryEntity != "" {
+		entity, port, structs, err := loadGRPCSources(adapterName, adapterPrimaryEntity)
+		if err != nil {
+			return err
+		}
+		if err := gen.GenerateGRPCService(adapterName, entity, port, structs); err != nil {
+			return fmt.Errorf("failed to generate adapter: %w", err)
+		}
+		if printDryRun(config) {
+			return nil
+		}
+		printGRPCNextSteps(out, config, port)
+		return nil
+	}
+
 	if err := gen.GeneratePrimary(adapterType, adapterName, adapterPrimaryEntity, adapterPort); err != nil {
 		return fmt.Errorf("failed to generate adapter: %w", err)
 	}
 
-	fmt.Println("\n✅ Primary adapter added successfully!")
-	fmt.Printf("\n📝 Next steps:\n")
-	fmt.Printf("  1. Implement the adapter methods\n")
-	fmt.Printf("  2. Wire up dependencies in the DI container\n")
-	fmt.Printf("  3. Add routes/endpoints as needed\n")
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Fprintln(out, "\n✅ Primary adapter added successfully!")
+	fmt.Fprintf(out, "\n📝 Next steps:\n")
+	if adapterType == "cli" {
+		fmt.Fprintf(out, "  1. Implement the command in New%sCommand\n", adapterName)
+		fmt.Fprintf(out, "  2. Register it in Commands (internal/adapters/%s/cli/cli.go)\n", config.AdapterInboundDir())
+		return nil
+	}
+	if adapterType == "http" && adapterPrimaryEntity != "" {
+		fmt.Fprintf(out, "  1. Map the request and response DTOs in the handler package\n")
+		fmt.Fprintf(out, "  2. The handlers are mounted in internal/adapters/%s/http/routes.go\n", config.AdapterInboundDir())
+		return nil
+	}
+	fmt.Fprintf(out, "  1. Implement the adapter methods\n")
+	fmt.Fprintf(out, "  2. Add routes/endpoints as needed\n")
 
 	return nil
 }
 
+// loadGRPCSources analyzes the project for the entity struct and the service
+// port of a gRPC adapter. The port is looked up as the --port interface, an
+// interface named after the adapter or <Entity>Service, and finally the method
+// set of the <Entity>Service struct. Packages with type errors are analyzed
+// too: their declarations are enough.
+func loadGRPCSources(adapterName, entityName string) (*analyzer.DomainStruct, *analyzer.PortInfo, []analyzer.DomainStruct, error) {
+	pkgs, err := analyzer.LoadProjectPartial(workingDir)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load project for semantic analysis: %w", err)
+	}
+
+	entity, err := analyzer.FindDomainStructByName(pkgs, entityName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("entity %q not found: %w", entityName, err)
+	}
+
+	serviceName := entityName + "Service"
+	for _, name := range []string{adapterPort, adapterName, serviceName} {
+		if name == "" {
+			continue
+		}
+		if port, err := analyzer.FindInterfaceByName(pkgs, name); err == nil {
+			return entity, port, analyzer.FindDomainStructs(pkgs), nil
+		}
+	}
+
+	port, err := analyzer.FindMethodsByName(pkgs, serviceName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("no service port found for %s: %w\nCreate it first: hexago add service %s --entity %s",
+			entityName, err, serviceName, entityName)
+	}
+
+	return entity, port, analyzer.FindDomainStructs(pkgs), nil
+}
+
+// printGRPCNextSteps prints what is left to do after generating a gRPC service
+func printGRPCNextSteps(w io.Writer, config *generator.ProjectConfig, port *analyzer.PortInfo) {
+	fmt.Fprintln(w, "\n✅ gRPC adapter added successfully!")
+	fmt.Fprintf(w, "   📋 Derived rpcs from %s (%d method(s))\n", port.Name, len(port.Methods))
+	fmt.Fprintf(w, "\n📝 Next steps:\n")
+	fmt.Fprintf(w, "  1. Install protoc, protoc-gen-go and protoc-gen-go-grpc\n")
+	fmt.Fprintf(w, "  2. Generate the protobuf code: go generate ./internal/adapters/%s/grpc/...\n", config.AdapterInboundDir())
+	fmt.Fprintf(w, "  3. Add the dependencies: go get google.golang.org/grpc google.golang.org/protobuf\n")
+	fmt.Fprintf(w, "  4. The server is started by %s, on :9090\n", generator.WiringFile)
+	fmt.Fprintf(w, "  5. Review the TODOs in the .proto for fields without a protobuf mapping\n")
+}
+
 func runAddAdapterSecondary(cmd *cobra.Command, args []string) error {
 	adapterType := args[0]
 	adapterName := args[1]
@@ -128,6 +227,7 @@ func runAddAdapterSecondary(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
+	config.DryRun = dryRun
 
 	fmt.Printf("📦 Adding secondary adapter: %s (%s)\n", adapterName, adapterType)
 	fmt.Printf("   Project: %s\n", config.ProjectName)
@@ -150,10 +250,33 @@ func runAddAdapterSecondary(cmd *cobra.Command, args []string) error {
 	}
 
 	gen := generator.NewAdapterGenerator(config)
+
+	// database + entity → CRUD SQL and table migration derived from the entity fields
+	if adapterType == "database" && adapterEntity != "" {
+		if entity := loadRepositoryEntity(adapterEntity); entity != nil {
+			if err := gen.GenerateRepository(adapterName, entity, adapterDialect); err != nil {
+				return fmt.Errorf("failed to generate adapter: %w", err)
+			}
+			if printDryRun(config) {
+				return nil
+			}
+			fmt.Println("\n✅ Secondary adapter added successfully!")
+			fmt.Printf("   📋 Derived the SQL from the %s fields\n", entity.Name)
+			fmt.Printf("\n📝 Next steps:\n")
+			fmt.Printf("  1. Review the generated migration in migrations/\n")
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "🔄 Falling back to generic generation\n")
+	}
+
 	if err := gen.GenerateSecondary(adapterType, adapterName, adapterEntity, adapterPort, portInfo); err != nil {
 		return fmt.Errorf("failed to generate adapter: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Secondary adapter added successfully!")
 	if fromPort != "" && portInfo != nil {
 		fmt.Printf("   📋 Inferred %d method(s) from %s port\n", len(portInfo.Methods), fromPort)
@@ -161,7 +284,29 @@ func runAddAdapterSecondary(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\n📝 Next steps:\n")
 	fmt.Printf("  1. Implement the port interface methods\n")
 	fmt.Printf("  2. Add database queries or external API calls\n")
-	fmt.Printf("  3. Wire up dependencies in the DI container\n")
+	if adapterType == "database" {
+		fmt.Printf("  3. Keep a New<Name>(db) constructor: %s wires it into the service of its entity\n", generator.WiringFile)
+	} else {
+		fmt.Printf("  3. Pass the adapter to the services that depend on its port\n")
+	}
 
 	return nil
 }
+
+// loadRepositoryEntity analyzes the project for the entity a database adapter
+// stores. A nil entity means it could not be found.
+func loadRepositoryEntity(entityName string) *analyzer.DomainStruct {
+	pkgs, err := analyzer.LoadProject(workingDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to load project for semantic analysis: %v\n", err)
+		return nil
+	}
+
+	entity, err := analyzer.FindDomainStructByName(pkgs, entityName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: entity %q not found: %v\n", entityName, err)
+		return nil
+	}
+
+	return entity
+}
@@ -89,6 +89,7 @@ func runAddDomainEntity(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
+	config.DryRun = dryRun
 
 	fmt.Printf("📦 Adding domain entity: %s\n", entityName)
 	fmt.Printf("   Project: %s\n\n", config.ProjectName)
@@ -105,6 +106,10 @@ func runAddDomainEntity(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate entity: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Domain entity added successfully!")
 	fmt.Printf("\n📝 Next steps:\n")
 	fmt.Printf("  1. Add business logic methods to the entity\n")
@@ -125,6 +130,7 @@ func runAddDomainValueObject(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
+	config.DryRun = dryRun
 
 	fmt.Printf("📦 Adding value object: %s\n", voName)
 	fmt.Printf("   Project: %s\n\n", config.ProjectName)
@@ -141,6 +147,10 @@ func runAddDomainValueObject(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate value object: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Value object added successfully!")
 	fmt.Printf("\n📝 Next steps:\n")
 	fmt.Printf("  1. Ensure immutability (no setter methods)\n")
@@ -11,12 +11,13 @@ import (
 )
 
 var (
-	migrationType string
+	migrationType       string
+	migrationFromEntity string
 )
 
 // addMigrationCmd represents the add migration command
 var addMigrationCmd = &cobra.Command{
-	Use:   "migration <name>",
+	Use:   "migration [name]",
 	Short: "Add a database migration",
 	Long: `Add a database migration file using golang-migrate format.
 
@@ -26,13 +27,25 @@ Generates sequentially numbered up and down migration files:
 
 Migration types:
   sql (default) - SQL migration files
-  go            - Go-based migrations (future)
+  go            - A Go file, migrations/000001_<name>.go, registering up and
+                  down functions that receive the *sql.DB and a transaction.
+                  Use it for data backfills that cannot be written in SQL.
+
+The Migrator in internal/infrastructure/database runs SQL and Go migrations
+in one sequence ordered by number. The migrate CLI only runs the SQL files.
+
+With --from-entity, the SQL is derived from the domain entity: the entity
+fields are compared against the table snapshot in .hexago/schema/ and the
+migration creates the table, or adds and drops the changed columns. The
+name defaults to create_<table>_table or alter_<table>_table.
 
 Example:
   hexago add migration create_users_table
   hexago add migration add_email_index
-  hexago add migration alter_products_table`,
-	Args: cobra.ExactArgs(1),
+  hexago add migration backfill_user_slugs --type go
+  hexago add migration --from-entity User
+  hexago add migration add_user_phone --from-entity User`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runAddMigration,
 }
 
@@ -40,13 +53,17 @@ func init() {
 	addCmd.AddCommand(addMigrationCmd)
 
 	addMigrationCmd.Flags().StringVarP(&migrationType, "type", "t", "sql", "Migration type (sql|go)")
+	addMigrationCmd.Flags().StringVarP(&migrationFromEntity, "from-entity", "e", "", "Domain entity to derive the SQL from, diffed against its schema snapshot")
 }
 
 func runAddMigration(cmd *cobra.Command, args []string) error {
-	migrationName := args[0]
+	var migrationName string
+	if len(args) > 0 {
+		migrationName = args[0]
+	}
 
 	// Validate migration name (should be snake_case)
-	if migrationName == "" {
+	if migrationName == "" && migrationFromEntity == "" {
 		return fmt.Errorf("migration name cannot be empty")
 	}
 
@@ -55,14 +72,19 @@ func runAddMigration(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid migration type '%s'. Valid types: sql, go", migrationType)
 	}
 
-	if migrationType == "go" {
-		return fmt.Errorf("go migrations not yet implemented. Use --type sql")
+	if migrationType == "go" && migrationFromEntity != "" {
+		return fmt.Errorf("--from-entity generates SQL migrations; it cannot be used with --type go")
 	}
 
 	config, err := generator.GetCurrentProjectConfig(workingDir)
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
+	config.DryRun = dryRun
+
+	if migrationFromEntity != "" {
+		return runAddMigrationFromEntity(config, migrationName)
+	}
 
 	fmt.Printf("📦 Adding migration: %s\n", migrationName)
 	fmt.Printf("   Project: %s\n", config.ProjectName)
@@ -70,11 +92,19 @@ func runAddMigration(cmd *cobra.Command, args []string) error {
 
 	// Generate migration
 	gen := generator.NewMigrationGenerator(config)
+	if migrationType == "go" {
+		return runAddGoMigration(config, gen, migrationName)
+	}
+
 	migrationNumber, err := gen.Generate(migrationName)
 	if err != nil {
 		return fmt.Errorf("failed to generate migration: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Migration added successfully!")
 	fmt.Printf("\n📝 Files created:\n")
 	fmt.Printf("   - migrations/%06d_%s.up.sql\n", migrationNumber, migrationName)
@@ -89,3 +119,59 @@ func runAddMigration(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runAddGoMigration generates a Go migration
+func runAddGoMigration(config *generator.ProjectConfig, gen *generator.MigrationGenerator, migrationName string) error {
+	migrationNumber, err := gen.GenerateGo(migrationName)
+	if err != nil {
+		return fmt.Errorf("failed to generate migration: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Migration added successfully!")
+	fmt.Printf("\n📝 Files created:\n")
+	fmt.Printf("   - migrations/%06d_%s.go\n", migrationNumber, migrationName)
+	fmt.Printf("\n📝 Next steps:\n")
+	fmt.Printf("  1. Implement Up and Down in the .go file; both run inside a transaction\n")
+	fmt.Printf("  2. Run migrations through the Migrator (database.NewMigrator(...).Up());\n")
+	fmt.Printf("     the migrate CLI skips Go migrations\n")
+
+	return nil
+}
+
+// runAddMigrationFromEntity generates the migration of the --from-entity table
+func runAddMigrationFromEntity(config *generator.ProjectConfig, migrationName string) error {
+	fmt.Printf("📦 Adding migration from entity: %s\n", migrationFromEntity)
+	fmt.Printf("   Project: %s\n", config.ProjectName)
+	fmt.Printf("   Dialect: %s\n\n", config.DBDialect)
+
+	entity := loadRepositoryEntity(migrationFromEntity)
+	if entity == nil {
+		return fmt.Errorf("failed to generate migration: entity %s could not be analyzed", migrationFromEntity)
+	}
+
+	gen := generator.NewMigrationGenerator(config)
+	migrationNumber, migrationName, err := gen.GenerateFromEntity(migrationName, entity)
+	if err != nil {
+		return fmt.Errorf("failed to generate migration: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Migration added successfully!")
+	fmt.Printf("\n📝 Files created:\n")
+	fmt.Printf("   - migrations/%06d_%s.up.sql\n", migrationNumber, migrationName)
+	fmt.Printf("   - migrations/%06d_%s.down.sql\n", migrationNumber, migrationName)
+	fmt.Printf("\n📝 Next steps:\n")
+	fmt.Printf("  1. Review the generated SQL (type changes are left as TODO comments)\n")
+	fmt.Printf("  2. Commit .hexago/schema/ with the migration\n")
+	fmt.Printf("  3. Run migrations:\n")
+	fmt.Printf("     make migrate-up\n")
+
+	return nil
+}
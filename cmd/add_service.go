@@ -66,6 +66,7 @@ func runAddService(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w\nMake sure you're in a hexagonal architecture project directory", err)
 	}
+	config.DryRun = dryRun
 
 	fmt.Printf("📦 Adding service: %s\n", serviceName)
 	fmt.Printf("   Project: %s\n", config.ProjectName)
@@ -95,6 +96,10 @@ func runAddService(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate service: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Service added successfully!")
 	if serviceFromPort != "" && portInfo != nil {
 		fmt.Printf("   📋 Inferred %d method(s) from %s port\n", len(portInfo.Methods), serviceFromPort)
@@ -62,6 +62,7 @@ func runAddTool(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w\nMake sure you're in a hexagonal architecture project directory", err)
 	}
+	config.DryRun = dryRun
 
 	fmt.Printf("📦 Adding %s tool: %s\n", toolType, toolName)
 	fmt.Printf("   Project: %s\n", config.ProjectName)
@@ -76,6 +77,10 @@ func runAddTool(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate tool: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Tool added successfully!")
 	fmt.Printf("\n📝 Files created:\n")
 	fmt.Printf("   - internal/infrastructure/%s/%s\n", toolType, utils.ToSnakeCase(toolName)+".go")
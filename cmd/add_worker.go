@@ -49,7 +49,7 @@ func init() {
 	addWorkerCmd.Flags().StringVarP(&workerType, "type", "t", "queue", "Worker type (queue|periodic|event)")
 	addWorkerCmd.Flags().StringVar(&workerInterval, "interval", "5m", "Interval for periodic workers (e.g., 5m, 1h)")
 	addWorkerCmd.Flags().IntVar(&workerWorkers, "workers", 5, "Number of concurrent workers for queue type")
-	addWorkerCmd.Flags().IntVar(&workerQueueSize, "queue-size", 100, "Queue size for queue-based workers")
+	addWorkerCmd.Flags().IntVar(&workerQueueSize, "queue-size", 100, "Queue size for queue workers, event buffer size for event workers")
 }
 
 func runAddWorker(cmd *cobra.Command, args []string) error {
@@ -74,6 +74,7 @@ func runAddWorker(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project: %w", err)
 	}
+	config.DryRun = dryRun
 
 	fmt.Printf("📦 Adding worker: %s (%s)\n", workerName, workerType)
 	fmt.Printf("   Project: %s\n", config.ProjectName)
@@ -83,6 +84,8 @@ func runAddWorker(cmd *cobra.Command, args []string) error {
 	} else if workerType == "queue" {
 		fmt.Printf("   Workers: %d\n", workerWorkers)
 		fmt.Printf("   Queue size: %d\n", workerQueueSize)
+	} else if workerType == "event" {
+		fmt.Printf("   Buffer size: %d\n", workerQueueSize)
 	}
 	fmt.Println()
 
@@ -99,13 +102,14 @@ func runAddWorker(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate worker: %w", err)
 	}
 
+	if printDryRun(config) {
+		return nil
+	}
+
 	fmt.Println("\n✅ Worker added successfully!")
 	fmt.Printf("\n📝 Next steps:\n")
 	fmt.Printf("  1. Implement the worker logic in the process method\n")
-	fmt.Printf("  2. Register the worker in cmd/run.go:\n")
-	fmt.Printf("     - Create worker instance\n")
-	fmt.Printf("     - Add to worker manager\n")
-	fmt.Printf("     - Start with context\n")
+	fmt.Printf("  2. The worker is registered with the worker manager in %s\n", generator.WiringFile)
 	fmt.Printf("  3. Test the worker with unit tests\n")
 
 	if workerType == "queue" {
@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import parent command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Scaffold components from an existing contract",
+	Long:  `Scaffold project components from a contract written before the code.`,
+}
+
+// importOpenAPICmd scaffolds entities, services and handlers from an OpenAPI spec
+var importOpenAPICmd = &cobra.Command{
+	Use:   "openapi <spec.yaml>",
+	Short: "Scaffold entities, services and HTTP handlers from an OpenAPI 3 spec",
+	Long: `Scaffold the project components described by an OpenAPI 3 spec (YAML or JSON).
+
+  - Component schemas become domain entities with their properties as fields
+    (schemas used only as request bodies or error payloads are left out)
+  - Each tag becomes a service, bound to the entity of the tagged paths
+  - Each path group (/orders, /orders/{id}) becomes an HTTP handler package;
+    its DTOs are built from the request and response schemas
+
+Components that already exist are skipped, so the import can be re-run after
+the spec grows. Operations the handler templates do not register (DELETE,
+PATCH, nested paths) are listed at the end.
+
+Example:
+  hexago import openapi api/openapi.yaml
+  hexago import openapi ../contracts/shop.yaml --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportOpenAPI,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importOpenAPICmd)
+}
+
+func runImportOpenAPI(cmd *cobra.Command, args []string) error {
+	specPath := args[0]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	fmt.Printf("📥 Importing OpenAPI spec: %s\n", specPath)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewOpenAPIGenerator(config)
+	result, err := gen.Import(spec)
+	if err != nil {
+		return fmt.Errorf("failed to import OpenAPI spec: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ OpenAPI spec imported successfully!")
+	printImported("Entities", result.Entities)
+	printImported("Services", result.Services)
+	printImported("Handlers", result.Handlers)
+	printImported("Skipped (already exist)", result.Skipped)
+	if len(result.Unmapped) > 0 {
+		fmt.Printf("\n⚠️  Operations to implement by hand:\n")
+		for _, op := range result.Unmapped {
+			fmt.Printf("  - %s\n", op)
+		}
+	}
+
+	fmt.Printf("\n📝 Next steps:\n")
+	fmt.Printf("  1. Map the request DTOs to the service inputs in handlers.go\n")
+	fmt.Printf("  2. Replace fields typed any with the entity or value object types\n")
+	fmt.Printf("  3. Add the repositories: hexago add adapter secondary database <Entity>Repository --entity <Entity>\n")
+
+	return nil
+}
+
+// printImported prints a labelled list of imported components
+func printImported(label string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("   %s: %s\n", label, strings.Join(names, ", "))
+}
@@ -5,12 +5,14 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/padiazg/hexago/internal/generator"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -19,6 +21,9 @@ var (
 	framework         string
 	adapterStyle      string
 	coreLogic         string
+	dbDialect         string
+	dbDriver          string
+	migrationVersions string
 	withDocker        bool
 	withExample       bool
 	withMigrations    bool
@@ -27,11 +32,12 @@ var (
 	withWorkers       bool
 	withObservability bool
 	inPlace           bool
+	initInteractive   bool
 )
 
 // initCmd represents the init command
 var initCmd = &cobra.Command{
-	Use:   "init <project-name>",
+	Use:   "init [project-name]",
 	Short: "Initialize a new hexagonal architecture project",
 	Long: `Initialize a new Go project with hexagonal architecture structure.
 
@@ -49,11 +55,30 @@ Architecture (Ports & Adapters) pattern, including:
 Project Types:
   http-server  - HTTP API server with web framework
   service      - Long-running daemon/service (no web framework for main logic)
+  cli          - Command-line application (Cobra subcommands as primary adapters)
+  job          - Run-to-completion job (batch imports, Kubernetes CronJobs)
+
+Database:
+  --db-dialect and --db-driver choose the SQL database used by database
+  adapters, migrations, config.go and compose.yaml. They are saved as
+  database.dialect and database.driver in .hexago.yaml.
+
+Migrations:
+  --migration-versioning numbers new migrations sequentially (000001) or by
+  UTC timestamp (YYYYMMDDHHMMSS), which keeps migrations added on parallel
+  branches from colliding. It is saved as migrations.versioning.
+
+Interactive mode:
+  Use --interactive to be prompted for every choice, with a summary and a
+  confirmation before anything is generated. It also starts automatically
+  when no flags are given and stdin is a terminal.
 
 Example:
   hexago init my-api --module github.com/user/my-api --project-type http-server --framework echo
-  hexago init my-service --module github.com/user/my-service --project-type service`,
-	Args: cobra.ExactArgs(1),
+  hexago init my-service --module github.com/user/my-service --project-type service
+  hexago init my-tool --module github.com/user/my-tool --project-type cli
+  hexago init --interactive`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runInit,
 }
 
@@ -64,10 +89,13 @@ func init() {
 	initCmd.Flags().StringVarP(&moduleName, "module", "m", "", "Go module name (e.g., github.com/user/my-app)")
 
 	// Project type and architecture choices
-	initCmd.Flags().StringVarP(&projectType, "project-type", "t", "http-server", "Project type (http-server|service)")
+	initCmd.Flags().StringVarP(&projectType, "project-type", "t", "http-server", "Project type (http-server|service|cli|job)")
 	initCmd.Flags().StringVarP(&framework, "framework", "f", "stdlib", "Web framework for http-server (echo|gin|chi|fiber|stdlib)")
 	initCmd.Flags().StringVar(&adapterStyle, "adapter-style", "primary-secondary", "Adapter naming style (primary-secondary|driver-driven)")
 	initCmd.Flags().StringVar(&coreLogic, "core-logic", "services", "Core business logic directory name (services|usecases)")
+	initCmd.Flags().StringVar(&dbDialect, "db-dialect", "postgres", "SQL dialect of the project database (postgres|mysql|sqlite)")
+	initCmd.Flags().StringVar(&dbDriver, "db-driver", "database/sql", "Go driver style of database adapters (database/sql|pgx|sqlx)")
+	initCmd.Flags().StringVar(&migrationVersions, "migration-versioning", "sequential", "Version numbering of new migrations (sequential|timestamp)")
 
 	// Optional features - all default to false for maximum flexibility
 	initCmd.Flags().BoolVar(&withDocker, "with-docker", false, "Generate Docker files")
@@ -78,14 +106,26 @@ func init() {
 	initCmd.Flags().BoolVar(&withWorkers, "with-workers", false, "Include worker pattern setup")
 	initCmd.Flags().BoolVar(&withObservability, "with-observability", false, "Include observability (health checks + metrics)")
 	initCmd.Flags().BoolVar(&inPlace, "in-place", false, "Generate project files directly in the working directory (no <name> subdirectory)")
+	initCmd.Flags().BoolVarP(&initInteractive, "interactive", "i", false, "Prompt for every option (default when no flags are given on a terminal)")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
-	projectName := args[0]
+	out := cmd.OutOrStdout()
 
-	// Validate project name
-	if err := validateProjectName(projectName); err != nil {
-		return err
+	// Run the wizard when asked to, or when a user on a terminal gave no
+	// flags of init: global ones like --verbose don't count
+	interactive := initInteractive || (changedFlags(cmd.LocalFlags()) == 0 && isTerminal())
+
+	var projectName string
+	if len(args) > 0 {
+		projectName = args[0]
+
+		// Validate project name
+		if err := validateProjectName(projectName); err != nil {
+			return err
+		}
+	} else if !interactive {
+		return fmt.Errorf("project name is required (or use --interactive)")
 	}
 
 	// Resolve output directory (working dir flag or CWD)
@@ -100,7 +140,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Load .hexago.yaml from outDir as a defaults layer (flags > yaml > hardcoded defaults)
 	if hexCfg, err := generator.LoadHexagoConfig(outDir); err == nil {
-		fmt.Println("ℹ️  Loading defaults from .hexago.yaml")
+		fmt.Fprintln(out, "ℹ️  Loading defaults from .hexago.yaml")
 		pc := hexCfg.ToProjectConfig()
 		if !cmd.Flags().Changed("module") && pc.ModuleName != "" {
 			moduleName = pc.ModuleName
@@ -117,6 +157,15 @@ func runInit(cmd *cobra.Command, args []string) error {
 		if !cmd.Flags().Changed("core-logic") && pc.CoreLogic != "" {
 			coreLogic = pc.CoreLogic
 		}
+		if !cmd.Flags().Changed("db-dialect") && pc.DBDialect != "" {
+			dbDialect = pc.DBDialect
+		}
+		if !cmd.Flags().Changed("db-driver") && pc.DBDriver != "" {
+			dbDriver = pc.DBDriver
+		}
+		if !cmd.Flags().Changed("migration-versioning") && pc.MigrationVersioning != "" {
+			migrationVersions = pc.MigrationVersioning
+		}
 		if !cmd.Flags().Changed("with-docker") {
 			withDocker = pc.WithDocker
 		}
@@ -140,10 +189,21 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Prompt for every choice, starting from the flag and .hexago.yaml values
+	var prompt *prompter
+	if interactive {
+		prompt = newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+
+		var err error
+		if projectName, err = runInitWizard(prompt, projectName); err != nil {
+			return err
+		}
+	}
+
 	// Generate module name if not provided
 	if moduleName == "" {
 		moduleName = projectName
-		fmt.Printf("ℹ️  No module name provided, using: %s\n", moduleName)
+		fmt.Fprintf(out, "ℹ️  No module name provided, using: %s\n", moduleName)
 	}
 
 	// Validate module name
@@ -163,7 +223,12 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 	} else if framework != "stdlib" {
 		// Warn if framework specified for non-http-server projects
-		fmt.Printf("⚠️  Warning: --framework is ignored for project type '%s' (only used for http-server)\n", projectType)
+		fmt.Fprintf(out, "⚠️  Warning: --framework is ignored for project type '%s' (only used for http-server)\n", projectType)
+	}
+
+	// CLI applications have no server to expose health checks and metrics on
+	if projectType == "cli" && withObservability {
+		fmt.Fprintln(out, "⚠️  Warning: --with-observability only generates internal/observability for cli projects; there is no server to register it on")
 	}
 
 	// Validate adapter style
@@ -176,6 +241,16 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Validate database dialect and driver style
+	if err := generator.ValidateDatabase(dbDialect, dbDriver); err != nil {
+		return err
+	}
+
+	// Validate migration versioning
+	if err := generator.ValidateMigrationVersioning(migrationVersions); err != nil {
+		return err
+	}
+
 	// Create project configuration
 	config := generator.NewProjectConfig(projectName, moduleName)
 	config.OutputDir = outDir
@@ -183,6 +258,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 	config.Framework = framework
 	config.AdapterStyle = adapterStyle
 	config.CoreLogic = coreLogic
+	config.DBDialect = dbDialect
+	config.DBDriver = dbDriver
+	config.MigrationVersioning = migrationVersions
 	config.WithDocker = withDocker
 	config.WithExample = withExample
 	config.WithMigrations = withMigrations
@@ -191,9 +269,21 @@ func runInit(cmd *cobra.Command, args []string) error {
 	config.WithWorkers = withWorkers
 	config.WithObservability = withObservability
 	config.InPlace = inPlace
+	config.DryRun = dryRun
 
 	// Print configuration
-	printProjectInfo(config)
+	printProjectInfo(out, config)
+
+	if interactive {
+		ok, err := prompt.Confirm("Generate the project?", true)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(out, "❌ Aborted, nothing was generated")
+			return nil
+		}
+	}
 
 	// Generate project
 	gen := generator.NewProjectGenerator(config)
@@ -201,9 +291,23 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate project: %w", err)
 	}
 
+	printDryRun(config)
 	return nil
 }
 
+// changedFlags returns the number of flags of flags set on the command line.
+// Unlike flags.NFlag, it counts the flags of the sets cobra derives from the
+// parsed one, such as LocalFlags.
+func changedFlags(flags *pflag.FlagSet) int {
+	n := 0
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			n++
+		}
+	})
+	return n
+}
+
 func validateProjectName(name string) error {
 	if name == "" {
 		return fmt.Errorf("project name cannot be empty")
@@ -239,10 +343,12 @@ func validateProjectType(pt string) error {
 	validTypes := map[string]bool{
 		"http-server": true,
 		"service":     true,
+		"cli":         true,
+		"job":         true,
 	}
 
 	if !validTypes[pt] {
-		return fmt.Errorf("invalid project type '%s'. Valid options: http-server, service", pt)
+		return fmt.Errorf("invalid project type '%s'. Valid options: http-server, service, cli, job", pt)
 	}
 
 	return nil
@@ -303,20 +409,30 @@ func validateDirectoryNotExists(path string) error {
 	return nil
 }
 
-func printProjectInfo(config *generator.ProjectConfig) {
-	fmt.Println("\n📋 Project Configuration:")
-	fmt.Printf("  Name:              %s\n", config.ProjectName)
-	fmt.Printf("  Module:            %s\n", config.ModuleName)
-	fmt.Printf("  Project Type:      %s\n", config.ProjectType)
+func printProjectInfo(w io.Writer, config *generator.ProjectConfig) {
+	fmt.Fprintln(w, "\n📋 Project Configuration:")
+	fmt.Fprintf(w, "  Name:              %s\n", config.ProjectName)
+	fmt.Fprintf(w, "  Module:            %s\n", config.ModuleName)
+	fmt.Fprintf(w, "  Project Type:      %s\n", config.ProjectType)
 	if config.IsHTTPServer() {
-		fmt.Printf("  Framework:         %s\n", config.Framework)
-	}
-	fmt.Printf("  Adapter Style:     %s\n", config.AdapterStyle)
-	fmt.Printf("  Core Logic:        %s\n", config.CoreLogic)
-	fmt.Printf("  Docker:            %v\n", config.WithDocker)
-	fmt.Printf("  Observability:     %v\n", config.WithObservability)
-	fmt.Printf("  Migrations:        %v\n", config.WithMigrations)
-	fmt.Printf("  Workers:           %v\n", config.WithWorkers)
-	fmt.Printf("  Example Code:      %v\n", config.WithExample)
-	fmt.Println()
+		fmt.Fprintf(w, "  Framework:         %s\n", config.Framework)
+	}
+	fmt.Fprintf(w, "  Adapter Style:     %s\n", config.AdapterStyle)
+	fmt.Fprintf(w, "  Core Logic:        %s\n", config.CoreLogic)
+	fmt.Fprintf(w, "  Database:          %s (%s)\n", config.DBDialect, config.DBDriver)
+	fmt.Fprintf(w, "  Docker:            %v\n", config.WithDocker)
+	fmt.Fprintf(w, "  Observability:     %v\n", config.WithObservability)
+	if !config.WithObservability {
+		fmt.Fprintf(w, "  Metrics:           %v\n", config.WithMetrics)
+	}
+	if config.WithMigrations {
+		fmt.Fprintf(w, "  Migrations:        %v (%s)\n", config.WithMigrations, config.MigrationVersioning)
+	} else {
+		fmt.Fprintf(w, "  Migrations:        %v\n", config.WithMigrations)
+	}
+	fmt.Fprintf(w, "  Workers:           %v\n", config.WithWorkers)
+	fmt.Fprintf(w, "  Explicit Ports:    %v\n", config.ExplicitPorts)
+	fmt.Fprintf(w, "  Example Code:      %v\n", config.WithExample)
+	fmt.Fprintf(w, "  In Place:          %v\n", config.InPlace)
+	fmt.Fprintln(w)
 }
@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import "fmt"
+
+// runInitWizard walks through every init choice, using the current flag
+// values as defaults, and stores the answers back into the flag variables.
+// It returns the (possibly prompted) project name.
+func runInitWizard(p *prompter, projectName string) (string, error) {
+	fmt.Fprintln(p.out, "🧙 Interactive project setup (press Enter to accept the [default])")
+	fmt.Fprintln(p.out)
+
+	var err error
+
+	if projectName, err = p.String("Project name", projectName, validateProjectName); err != nil {
+		return "", err
+	}
+
+	moduleDefault := moduleName
+	if moduleDefault == "" {
+		moduleDefault = projectName
+	}
+	if moduleName, err = p.String("Go module name", moduleDefault, validateModuleName); err != nil {
+		return "", err
+	}
+
+	if projectType, err = p.Choice("Project type", []string{"http-server", "service", "cli", "job"}, projectType); err != nil {
+		return "", err
+	}
+
+	if projectType == "http-server" {
+		if framework, err = p.Choice("Web framework", []string{"stdlib", "echo", "gin", "chi", "fiber"}, framework); err != nil {
+			return "", err
+		}
+	} else {
+		framework = "stdlib"
+	}
+
+	if adapterStyle, err = p.Choice("Adapter naming style", []string{"primary-secondary", "driver-driven"}, adapterStyle); err != nil {
+		return "", err
+	}
+
+	if coreLogic, err = p.Choice("Core business logic directory", []string{"services", "usecases"}, coreLogic); err != nil {
+		return "", err
+	}
+
+	if dbDialect, err = p.Choice("SQL database dialect", []string{"postgres", "mysql", "sqlite"}, dbDialect); err != nil {
+		return "", err
+	}
+
+	// pgx is PostgreSQL only
+	drivers := []string{"database/sql", "sqlx"}
+	if dbDialect == "postgres" {
+		drivers = []string{"database/sql", "pgx", "sqlx"}
+	} else if dbDriver == "pgx" {
+		dbDriver = "database/sql"
+	}
+	if dbDriver, err = p.Choice("Database driver style", drivers, dbDriver); err != nil {
+		return "", err
+	}
+
+	features := []struct {
+		label string
+		value *bool
+	}{
+		{"Generate Docker files?", &withDocker},
+		{"Include observability (health checks + metrics)?", &withObservability},
+		{"Include database migration setup?", &withMigrations},
+		{"Include worker pattern setup?", &withWorkers},
+		{"Create explicit ports/ directory?", &explicitPorts},
+		{"Include example code?", &withExample},
+		{"Generate files directly in the working directory (no <name> subdirectory)?", &inPlace},
+	}
+	for _, feature := range features {
+		if *feature.value, err = p.Confirm(feature.label, *feature.value); err != nil {
+			return "", err
+		}
+
+		// Observability includes the metrics already
+		if feature.value == &withObservability && !withObservability {
+			if withMetrics, err = p.Confirm("Include Prometheus metrics?", withMetrics); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if withMigrations {
+		if migrationVersions, err = p.Choice("Migration versioning", []string{"sequential", "timestamp"}, migrationVersions); err != nil {
+			return "", err
+		}
+	}
+
+	return projectName, nil
+}
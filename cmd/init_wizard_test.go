@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunInitWizard(t *testing.T) {
+	projectType, framework, adapterStyle, coreLogic = "http-server", "stdlib", "primary-secondary", "services"
+	dbDialect, dbDriver, moduleName = "postgres", "database/sql", ""
+	withObservability, withMetrics, withMigrations = false, false, false
+
+	input := strings.Join([]string{
+		"my-api",  // project name
+		"",        // module
+		"service", // project type: no framework prompt
+		"", "", "", "",
+		"n", // docker
+		"n", // observability
+		"y", // metrics
+		"n", "n", "n", "n", "n",
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	name, err := runInitWizard(newPrompter(strings.NewReader(input), &out), "")
+	if err != nil {
+		t.Fatalf("runInitWizard() error = %v\n%s", err, out.String())
+	}
+
+	if name != "my-api" || moduleName != "my-api" || projectType != "service" {
+		t.Errorf("name, module, type = %q, %q, %q", name, moduleName, projectType)
+	}
+	if !withMetrics {
+		t.Error("withMetrics = false, want true")
+	}
+	if !strings.Contains(out.String(), "Include Prometheus metrics?") {
+		t.Errorf("metrics prompt missing:\n%s", out.String())
+	}
+}
+
+func TestChangedFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "no flags", args: []string{"init", "demo"}, want: 0},
+		{name: "global flag only", args: []string{"init", "demo", "--verbose"}, want: 0},
+		{name: "init flag", args: []string{"init", "demo", "--verbose", "--with-docker"}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "hexago"}
+			root.PersistentFlags().Bool("verbose", false, "")
+			var got int
+			init := &cobra.Command{
+				Use: "init",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					got = changedFlags(cmd.LocalFlags())
+					return nil
+				},
+			}
+			init.Flags().Bool("with-docker", false, "")
+			root.AddCommand(init)
+
+			root.SetArgs(tt.args)
+			if err := root.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("changedFlags() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
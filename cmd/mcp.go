@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -58,13 +59,19 @@ following Hexagonal Architecture (Ports & Adapters).
 Required:  working_directory, name
 Optional:
   module          Go module path. E.g. "github.com/user/my-api". Defaults to name.
-  project_type    "http-server" (default) | "service"
+  project_type    "http-server" (default) | "service" | "cli" | "job"
   framework       "stdlib" (default) | "echo" | "gin" | "chi" | "fiber"
                   Only used when project_type=http-server.
   adapter_style   "primary-secondary" (default) | "driver-driven"
                   Controls directory names: adapters/primary+secondary vs adapters/driver+driven.
   core_logic      "services" (default) | "usecases"
                   Controls the directory name inside internal/core/.
+  db_dialect      "postgres" (default) | "mysql" | "sqlite"
+  db_driver       "database/sql" (default) | "pgx" (postgres only) | "sqlx"
+                  SQL database of database adapters, migrations, config.go and compose.yaml.
+  migration_versioning  "sequential" (default) | "timestamp"
+                  Numbering of new migrations; timestamps (YYYYMMDDHHMMSS) keep
+                  migrations added on parallel branches from colliding.
   in_place        bool — generate files directly into working_directory (no <name> subfolder).
                   Use when working_directory is already the intended project root.
 
@@ -126,7 +133,7 @@ Required:  working_directory, direction, adapter_type, name  (PascalCase)
   direction      "primary"   — inbound: receives requests (HTTP handler, gRPC server, queue consumer)
                | "secondary" — outbound: calls external systems (DB repo, API client, cache)
 
-  adapter_type   For primary:   "http" | "grpc" | "queue"
+  adapter_type   For primary:   "http" | "grpc" | "queue" | "cli"
                  For secondary: "database" | "external" | "cache"
                  Any other string is accepted and used as the subdirectory name.
 
@@ -134,6 +141,7 @@ Examples:
   direction=primary,   adapter_type=http,     name=UserHandler
   direction=primary,   adapter_type=grpc,     name=OrderService
   direction=primary,   adapter_type=queue,    name=PaymentConsumer
+  direction=primary,   adapter_type=cli,      name=ExportUsers
   direction=secondary, adapter_type=database,  name=UserRepository
   direction=secondary, adapter_type=external,  name=EmailClient
   direction=secondary, adapter_type=cache,     name=SessionCache
@@ -149,7 +157,7 @@ Optional:
   worker_type   "queue" (default) | "periodic" | "event"
   interval      Duration string for periodic workers. Default "5m". E.g. "30s", "1h", "15m".
   workers       int — goroutine pool size for queue workers. Default 5.
-  queue_size    int — buffered channel size for queue workers. Default 100.
+  queue_size    int — buffered channel size for queue and event workers. Default 100.
 
 Examples:
   name=EmailWorker,       worker_type=queue,    workers=10, queue_size=200
@@ -161,11 +169,19 @@ Examples:
 ────────────────────────────────────────────────────────────────────────────────
 
 Generated: migrations/<seq>_<name>.up.sql + migrations/<seq>_<name>.down.sql
+           or migrations/<seq>_<name>.go with migration_type "go"
 Sequence number is auto-incremented from existing migrations.
+Go migrations register Up/Down functions (ctx, *sql.DB, *sql.Tx) for data
+backfills; the generated Migrator runs SQL and Go migrations in one sequence.
 
-Required:  working_directory, name  (snake_case, e.g. "create_users_table")
+Required:  working_directory, and name (snake_case, e.g. "create_users_table")
+           or from_entity
 Optional:
   migration_type   "sql" (default) | "go"
+  from_entity      Domain entity to diff against .hexago/schema/<table>.yaml.
+                   Emits CREATE TABLE, or ALTER TABLE ADD/DROP COLUMN, plus the
+                   matching down migration. name then defaults to
+                   create_<table>_table / alter_<table>_table.
 
 ────────────────────────────────────────────────────────────────────────────────
 ## hexago_add_tool — add an infrastructure utility
@@ -189,6 +205,30 @@ Examples:
   tool_type=mapper,     name=UserMapper
   tool_type=middleware,  name=AuthMiddleware
 
+────────────────────────────────────────────────────────────────────────────────
+## hexago_openapi_generate — generate an OpenAPI 3 spec from the HTTP handlers
+────────────────────────────────────────────────────────────────────────────────
+
+Generated: api/openapi.yaml (or output)
+
+Required:  working_directory
+Optional:
+  output        Spec path relative to the project root. Default "api/openapi.yaml".
+  title         API title. Defaults to "<project> API".
+  api_version   API version. Default "0.1.0".
+  base_path     Path the handlers are mounted on. Default "/api/v1".
+  swagger_ui    bool — also generate internal/adapters/<inbound>/http/docs/ serving Swagger UI.
+
+────────────────────────────────────────────────────────────────────────────────
+## hexago_import_openapi — scaffold entities, services and handlers from a spec
+────────────────────────────────────────────────────────────────────────────────
+
+Required:  working_directory, spec
+  spec          Path to an OpenAPI 3 spec (YAML or JSON), absolute or relative to the project root.
+
+Generated: one domain entity per component schema (request bodies and error payloads excluded),
+one service per tag, one HTTP handler package per path group. Existing components are skipped.
+
 ────────────────────────────────────────────────────────────────────────────────
 ## hexago_validate — validate architecture compliance
 ────────────────────────────────────────────────────────────────────────────────
@@ -196,7 +236,65 @@ Examples:
 Required:  working_directory
 
 Checks dependency direction (adapters → core, never core → adapters), package organization,
-and naming conventions. Returns passed checks, warnings, and errors.`
+and naming conventions. Layer dependencies and the third-party and standard library packages
+each layer uses are checked against the architecture rules of .hexago.yaml, if any.
+Returns passed checks, warnings, and errors.
+
+────────────────────────────────────────────────────────────────────────────────
+## hexago_migration_check — check migration versions and files
+────────────────────────────────────────────────────────────────────────────────
+
+Required:  working_directory
+Optional:
+  base   Git revision (e.g. "origin/main"); migrations added since then must
+         sort after its newest migration.
+
+Reports duplicate versions and SQL migrations missing their up or down file.
+
+────────────────────────────────────────────────────────────────────────────────
+## hexago_remove — remove a component scaffolded with hexago_add_*
+────────────────────────────────────────────────────────────────────────────────
+
+Required:  working_directory, component, name
+Optional:
+  adapter_type   For component "adapter_primary" / "adapter_secondary" / "tool": the type
+  entity         For component "domain_valueobject": the entity it is co-located with
+
+  component      "service" | "domain_entity" | "domain_valueobject" | "adapter_primary"
+               | "adapter_secondary" | "worker" | "tool" | "migration"
+
+Deletes the generated files and regenerates services.go. Reports packages that still import
+the deleted code; fix those references afterwards.
+
+────────────────────────────────────────────────────────────────────────────────
+## hexago_rename_entity — rename a domain entity across core and adapters
+────────────────────────────────────────────────────────────────────────────────
+
+Required:  working_directory, old_name, new_name (PascalCase)
+
+Moves the domain, service, HTTP/gRPC handler and database adapter packages of the entity
+and renames the identifiers derived from its name, with their uses. String literals (table
+names, routes) are left alone.
+
+────────────────────────────────────────────────────────────────────────────────
+## hexago_status — list edited and deleted generated files
+────────────────────────────────────────────────────────────────────────────────
+
+Required:  working_directory
+
+Compares the project with the manifest of generated files in .hexago.yaml.
+Call it before regenerating or removing code to see which files hold hand edits.
+
+────────────────────────────────────────────────────────────────────────────────
+## hexago_upgrade — re-apply newer templates to a project
+────────────────────────────────────────────────────────────────────────────────
+
+Required:  working_directory
+
+Renders the project templates of this hexago version and merges them into the
+project: unedited files are replaced, edited files are three-way merged against
+the output recorded in .hexago/base/, overlapping edits get conflict markers.
+Fails while conflicts are left; resolve them, then run go mod tidy.`
 
 func init() {
 	rootCmd.AddCommand(mcpCmd)
@@ -264,8 +362,10 @@ Example call:
 			mcp.WithString("project_type",
 				mcp.Description(`Project type:
   http-server — HTTP API server with a web framework (default)
-  service     — long-running daemon with no HTTP layer`),
-				mcp.Enum("http-server", "service"),
+  service     — long-running daemon with no HTTP layer
+  cli         — command-line application; subcommands are primary adapters
+  job         — run-to-completion job with a deadline and exit code`),
+				mcp.Enum("http-server", "service", "cli", "job"),
 			),
 			mcp.WithString("framework",
 				mcp.Description("Web framework. Only relevant when project_type=http-server. Default: stdlib."),
@@ -283,6 +383,18 @@ Example call:
   usecases  — internal/core/usecases/`),
 				mcp.Enum("services", "usecases"),
 			),
+			mcp.WithString("db_dialect",
+				mcp.Description("SQL dialect of the project database. Default: postgres."),
+				mcp.Enum("postgres", "mysql", "sqlite"),
+			),
+			mcp.WithString("db_driver",
+				mcp.Description("Go driver style of database adapters. pgx requires db_dialect=postgres. Default: database/sql."),
+				mcp.Enum("database/sql", "pgx", "sqlx"),
+			),
+			mcp.WithString("migration_versioning",
+				mcp.Description("Numbering of new migrations: sequential (000001) or timestamp (YYYYMMDDHHMMSS). Default: sequential."),
+				mcp.Enum("sequential", "timestamp"),
+			),
 			mcp.WithBoolean("with_docker",
 				mcp.Description("Generate a multi-stage Dockerfile and docker-compose.yml."),
 			),
@@ -328,6 +440,15 @@ Example call:
 			if v, _ := args["core_logic"].(string); v != "" {
 				cliArgs = append(cliArgs, "--core-logic", v)
 			}
+			if v, _ := args["db_dialect"].(string); v != "" {
+				cliArgs = append(cliArgs, "--db-dialect", v)
+			}
+			if v, _ := args["db_driver"].(string); v != "" {
+				cliArgs = append(cliArgs, "--db-driver", v)
+			}
+			if v, _ := args["migration_versioning"].(string); v != "" {
+				cliArgs = append(cliArgs, "--migration-versioning", v)
+			}
 			if v, _ := args["with_docker"].(bool); v {
 				cliArgs = append(cliArgs, "--with-docker")
 			}
@@ -525,7 +646,7 @@ Adapters connect the core to the outside world. Two directions:
 
   primary   (inbound)  — drives the application; receives requests from external actors.
                          Lives in internal/adapters/primary/<adapter_type>/.
-                         Types: http, grpc, queue
+                         Types: http, grpc, queue, cli
                          E.g. UserHandler (HTTP), OrderConsumer (queue)
 
   secondary (outbound) — driven by the application; talks to external systems.
@@ -555,7 +676,7 @@ Example calls:
 			),
 			mcp.WithString("adapter_type",
 				mcp.Description(`Implementation technology:
-  For primary:   http, grpc, queue
+  For primary:   http, grpc, queue, cli
   For secondary: database, external, cache`),
 				mcp.Required(),
 			),
@@ -566,7 +687,14 @@ Example calls:
 			mcp.WithString("entity",
 				mcp.Description(`Domain entity this adapter serves (PascalCase). Behaviour by direction:
   primary   http — generates sub-package with two files: <snake_entity>.go (Config/DTOs) + handlers.go (List/Create/GetByID/Update)
-  secondary database — generates sub-package implementing the entity's Repository port`),
+                   and mounts it in the hexago:routes region of http/routes.go
+  primary   grpc — generates api/proto/<entities>/v1/<entities>.proto from the entity fields and the service
+                   port methods, plus server.go/mapper.go in a sub-package and pkg/grpcserver
+  secondary database — generates sub-package implementing the entity's Repository port with CRUD SQL
+                       derived from the entity fields, plus a migration creating its table`),
+			),
+			mcp.WithString("dialect",
+				mcp.Description("SQL dialect of a secondary database adapter generated with entity. Default: database.dialect from .hexago.yaml."),
 			),
 			mcp.WithString("port",
 				mcp.Description("Port interface name to implement (only used with explicit_ports projects). E.g. UserRepository, EmailSender."),
@@ -582,6 +710,9 @@ Example calls:
 			if v, _ := args["entity"].(string); v != "" {
 				cliArgs = append(cliArgs, "--entity", v)
 			}
+			if v, _ := args["dialect"].(string); v != "" {
+				cliArgs = append(cliArgs, "--dialect", v)
+			}
 			if v, _ := args["port"].(string); v != "" {
 				cliArgs = append(cliArgs, "--port", v)
 			}
@@ -603,6 +734,9 @@ Worker types:
   periodic — single goroutine that ticks at a fixed interval
              Params: interval (e.g. "5m", "1h", "30s")
   event    — goroutine that reacts to external events via a channel
+             Params: queue_size (event buffer)
+
+The worker is registered in the composition root, internal/app/wire_gen.go.
 
 Example calls:
   name: "EmailWorker",        worker_type: "queue",    workers: 5, queue_size: 100
@@ -627,7 +761,7 @@ Example calls:
 				mcp.Description("Number of concurrent goroutines for queue workers. Default: 5."),
 			),
 			mcp.WithNumber("queue_size",
-				mcp.Description("Buffered channel size for queue workers. Default: 100."),
+				mcp.Description("Buffered channel size for queue and event workers. Default: 100."),
 			),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -666,17 +800,20 @@ Use snake_case for the migration name to describe the schema change.
 Example calls:
   name: "create_users_table"
   name: "add_email_index_to_users"
-  name: "alter_orders_add_status_column"`),
+  name: "alter_orders_add_status_column"
+  from_entity: "User"`),
 			mcp.WithString("working_directory",
 				mcp.Description("Absolute path to the project root (the directory containing go.mod and migrations/)."),
 				mcp.Required(),
 			),
 			mcp.WithString("name",
-				mcp.Description("Migration name in snake_case describing the schema change. E.g. create_users_table, add_email_index."),
-				mcp.Required(),
+				mcp.Description("Migration name in snake_case describing the schema change. E.g. create_users_table, add_email_index. Optional with from_entity."),
+			),
+			mcp.WithString("from_entity",
+				mcp.Description("Domain entity (PascalCase) whose fields are diffed against the stored schema snapshot to generate the migration SQL."),
 			),
 			mcp.WithString("migration_type",
-				mcp.Description("Migration format: sql (default) or go."),
+				mcp.Description("Migration format: sql (default) or go. Go migrations register Up/Down functions receiving *sql.DB and a transaction, for data backfills."),
 				mcp.Enum("sql", "go"),
 			),
 		),
@@ -684,10 +821,16 @@ Example calls:
 			args := req.GetArguments()
 			wd, _ := args["working_directory"].(string)
 			name, _ := args["name"].(string)
-			cliArgs := []string{"--working-directory", wd, "add", "migration", name}
+			cliArgs := []string{"--working-directory", wd, "add", "migration"}
+			if name != "" {
+				cliArgs = append(cliArgs, name)
+			}
 			if v, _ := args["migration_type"].(string); v != "" {
 				cliArgs = append(cliArgs, "--type", v)
 			}
+			if v, _ := args["from_entity"].(string); v != "" {
+				cliArgs = append(cliArgs, "--from-entity", v)
+			}
 			return toolResult(runSelf(ctx, cliArgs...))
 		},
 	)
@@ -744,6 +887,95 @@ Example calls:
 		},
 	)
 
+	// hexago_openapi_generate
+	s.AddTool(
+		mcp.NewTool("hexago_openapi_generate",
+			mcp.WithDescription(`Generate an OpenAPI 3 spec from the HTTP handler packages.
+
+Paths and methods come from the routes registered in each handler package's Configure,
+schemas from the DTO structs and their json tags. DTOs still containing a TODO are
+completed with the domain entity fields.
+
+Run again after adding or changing HTTP handlers to keep the spec in sync.
+
+Example calls:
+  working_directory: "/home/user/projects/my-api"
+  working_directory: "/home/user/projects/my-api", title: "Shop API", swagger_ui: true`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing go.mod and internal/)."),
+				mcp.Required(),
+			),
+			mcp.WithString("output",
+				mcp.Description("Spec path relative to the project root. Default api/openapi.yaml."),
+			),
+			mcp.WithString("title",
+				mcp.Description("API title. Defaults to \"<project> API\"."),
+			),
+			mcp.WithString("api_version",
+				mcp.Description("API version. Default 0.1.0."),
+			),
+			mcp.WithString("base_path",
+				mcp.Description("Path the handlers are mounted on. Default /api/v1."),
+			),
+			mcp.WithBoolean("swagger_ui",
+				mcp.Description("Also generate a docs handler package serving Swagger UI and the spec."),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			cliArgs := []string{"--working-directory", wd, "openapi", "generate"}
+			if v, _ := args["output"].(string); v != "" {
+				cliArgs = append(cliArgs, "--output", v)
+			}
+			if v, _ := args["title"].(string); v != "" {
+				cliArgs = append(cliArgs, "--title", v)
+			}
+			if v, _ := args["api_version"].(string); v != "" {
+				cliArgs = append(cliArgs, "--api-version", v)
+			}
+			if v, _ := args["base_path"].(string); v != "" {
+				cliArgs = append(cliArgs, "--base-path", v)
+			}
+			if v, _ := args["swagger_ui"].(bool); v {
+				cliArgs = append(cliArgs, "--swagger-ui")
+			}
+			return toolResult(runSelf(ctx, cliArgs...))
+		},
+	)
+
+	// hexago_import_openapi
+	s.AddTool(
+		mcp.NewTool("hexago_import_openapi",
+			mcp.WithDescription(`Scaffold domain entities, services and HTTP handler packages from an OpenAPI 3 spec.
+
+Component schemas become entities with their properties as fields, each tag becomes a
+service and each path group (/orders, /orders/{id}) becomes a handler package with DTOs
+built from the request and response schemas. Existing components are skipped.
+
+Example call:
+  working_directory: "/home/user/projects/my-api", spec: "api/openapi.yaml"`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing go.mod and internal/)."),
+				mcp.Required(),
+			),
+			mcp.WithString("spec",
+				mcp.Description("Path to the OpenAPI 3 spec, absolute or relative to the project root."),
+				mcp.Required(),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			spec, _ := args["spec"].(string)
+			if !filepath.IsAbs(spec) {
+				spec = filepath.Join(wd, spec)
+			}
+			cliArgs := []string{"--working-directory", wd, "import", "openapi", spec}
+			return toolResult(runSelf(ctx, cliArgs...))
+		},
+	)
+
 	// hexago_validate
 	s.AddTool(
 		mcp.NewTool("hexago_validate",
@@ -756,6 +988,10 @@ Checks performed:
   ✓ Dependency direction is always inward (adapters → core, never core → adapters)
   ✓ Proper package organization and naming conventions
 
+Layer dependencies are checked against the architecture rules declared in
+.hexago.yaml (architecture.layers / architecture.rules), the defaults above otherwise.
+Each violation names the rule it breaks, e.g. "domain-isolation: ...".
+
 Returns a structured report with passed checks, warnings, and errors.
 Call this after every hexago_add_* operation to catch violations early.
 
@@ -773,4 +1009,194 @@ Example call:
 			return toolResult(runSelf(ctx, cliArgs...))
 		},
 	)
+
+	// hexago_remove
+	s.AddTool(
+		mcp.NewTool("hexago_remove",
+			mcp.WithDescription(`Remove a component scaffolded with one of the hexago_add_* tools.
+
+Deletes the sub-package (services, entities, database adapters, per-entity http/grpc handlers)
+or the file and its test (workers, tools, other adapters), regenerates services.go, and drops
+the files from the manifest in .hexago.yaml. Packages that still import the deleted code are
+reported as warnings. Migrations are removed by name or version and must not have been applied.
+
+Example calls:
+  component: "service",           name: "Category"
+  component: "adapter_secondary", adapter_type: "database", name: "Users"
+  component: "domain_valueobject", name: "Address", entity: "User"
+  component: "migration",         name: "create_users_table"`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing go.mod and internal/)."),
+				mcp.Required(),
+			),
+			mcp.WithString("component",
+				mcp.Description("Kind of component to remove."),
+				mcp.Required(),
+				mcp.Enum("service", "domain_entity", "domain_valueobject", "adapter_primary", "adapter_secondary", "worker", "tool", "migration"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Component name as given to hexago_add_*; a migration name or version."),
+				mcp.Required(),
+			),
+			mcp.WithString("adapter_type",
+				mcp.Description(`Adapter or tool type:
+  adapter_primary:   http, grpc, queue, cli
+  adapter_secondary: database, external, cache
+  tool:              logger, validator, mapper, middleware`),
+			),
+			mcp.WithString("entity",
+				mcp.Description("Entity an entity-bound value object is co-located with."),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			component, _ := args["component"].(string)
+			name, _ := args["name"].(string)
+			adapterType, _ := args["adapter_type"].(string)
+			cliArgs := []string{"--working-directory", wd, "remove"}
+			switch component {
+			case "domain_entity":
+				cliArgs = append(cliArgs, "domain", "entity", name)
+			case "domain_valueobject":
+				cliArgs = append(cliArgs, "domain", "valueobject", name)
+				if v, _ := args["entity"].(string); v != "" {
+					cliArgs = append(cliArgs, "--entity", v)
+				}
+			case "adapter_primary":
+				cliArgs = append(cliArgs, "adapter", "primary", adapterType, name)
+			case "adapter_secondary":
+				cliArgs = append(cliArgs, "adapter", "secondary", adapterType, name)
+			case "tool":
+				cliArgs = append(cliArgs, "tool", adapterType, name)
+			default:
+				cliArgs = append(cliArgs, component, name)
+			}
+			return toolResult(runSelf(ctx, cliArgs...))
+		},
+	)
+
+	// hexago_rename_entity
+	s.AddTool(
+		mcp.NewTool("hexago_rename_entity",
+			mcp.WithDescription(`Rename a domain entity across the layers hexago generated for it.
+
+The domain, service, HTTP/gRPC handler and database adapter sub-packages move to the package
+of the new name (categories → productcategories), and the identifiers derived from the entity
+name (Category, NewCategory, CategoryRepository, categoriesDomain, ...) are renamed with every
+use of them, using the type information of the project. services.go is rebuilt.
+
+String literals are left alone: rename tables with a migration.
+
+Example call:
+  old_name: "Category", new_name: "ProductCategory"`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing go.mod and internal/)."),
+				mcp.Required(),
+			),
+			mcp.WithString("old_name",
+				mcp.Description("Current entity name in PascalCase, e.g. \"Category\"."),
+				mcp.Required(),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New entity name in PascalCase, e.g. \"ProductCategory\"."),
+				mcp.Required(),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			oldName, _ := args["old_name"].(string)
+			newName, _ := args["new_name"].(string)
+			return toolResult(runSelf(ctx, "--working-directory", wd, "rename", "entity", oldName, newName))
+		},
+	)
+
+	// hexago_status
+	s.AddTool(
+		mcp.NewTool("hexago_status",
+			mcp.WithDescription(`List the generated files of a project that were modified by hand or deleted.
+
+hexago records every file it renders, with its template and a content hash, in the generated
+section of .hexago.yaml. Untouched files are only counted unless all is set.
+
+Example call:
+  working_directory: "/home/user/projects/my-api"`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing .hexago.yaml)."),
+				mcp.Required(),
+			),
+			mcp.WithBoolean("all",
+				mcp.Description("Also list the untouched files."),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			cliArgs := []string{"--working-directory", wd, "status"}
+			if v, _ := args["all"].(bool); v {
+				cliArgs = append(cliArgs, "--all")
+			}
+			return toolResult(runSelf(ctx, cliArgs...))
+		},
+	)
+
+	// hexago_upgrade
+	s.AddTool(
+		mcp.NewTool("hexago_upgrade",
+			mcp.WithDescription(`Re-apply the templates of this hexago version to an existing project.
+
+Files the user did not edit are replaced with the new template output. Edited files are
+three-way merged with the output recorded at generation time (.hexago/base/); overlapping
+edits are marked with <<<<<<< / ||||||| / ======= / >>>>>>> conflict markers and the
+call fails until they are resolved.
+
+Example call:
+  working_directory: "/home/user/projects/my-api"`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing .hexago.yaml)."),
+				mcp.Required(),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			cliArgs := []string{"--working-directory", wd, "upgrade"}
+			return toolResult(runSelf(ctx, cliArgs...))
+		},
+	)
+
+	// hexago_migration_check
+	s.AddTool(
+		mcp.NewTool("hexago_migration_check",
+			mcp.WithDescription(`Check the migrations in migrations/ for problems.
+
+Checks performed:
+  ✓ No two migrations share a version
+  ✓ Every SQL migration has an .up.sql and a .down.sql file
+  ✓ With base, migrations added since that git revision sort after its newest migration
+
+Call this after hexago_add_migration, or before merging a branch that adds migrations.
+
+Example call:
+  working_directory: "/home/user/projects/my-api"
+  base: "origin/main"`),
+			mcp.WithString("working_directory",
+				mcp.Description("Absolute path to the project root (the directory containing go.mod and migrations/)."),
+				mcp.Required(),
+			),
+			mcp.WithString("base",
+				mcp.Description("Git revision to compare against for out-of-order migrations, e.g. origin/main."),
+			),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			wd, _ := args["working_directory"].(string)
+			cliArgs := []string{"--working-directory", wd, "migration", "check"}
+			if v, _ := args["base"].(string); v != "" {
+				cliArgs = append(cliArgs, "--base", v)
+			}
+			return toolResult(runSelf(ctx, cliArgs...))
+		},
+	)
 }
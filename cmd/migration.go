@@ -0,0 +1,28 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// migrationCmd represents the migration command
+var migrationCmd = &cobra.Command{
+	Use:   "migration",
+	Short: "Inspect the database migrations of a project",
+	Long: `Inspect the database migrations in migrations/.
+
+Available subcommands:
+  check  - Detect duplicate or out-of-order versions and missing down files
+
+Use 'hexago add migration' to create migrations.
+
+Example:
+  hexago migration check
+  hexago migration check --base origin/main`,
+}
+
+func init() {
+	rootCmd.AddCommand(migrationCmd)
+}
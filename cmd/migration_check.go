@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var migrationCheckBase string
+
+// migrationCheckCmd represents the migration check command
+var migrationCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check migration versions and files",
+	Long: `Check the migrations in migrations/ and exit with an error on problems,
+so it can gate CI.
+
+Checks performed:
+  ✓ No two migrations share a version
+  ✓ Every SQL migration has an .up.sql and a .down.sql file
+  ✓ With --base, migrations added since the base revision sort after its
+    newest migration. golang-migrate never applies a version below the
+    current one of a database, so a branch merged after a newer migration
+    must renumber its own.
+
+Timestamp versioning (migrations.versioning: timestamp in .hexago.yaml)
+avoids most collisions between parallel branches.
+
+Example:
+  hexago migration check
+  hexago migration check --base origin/main`,
+	Args: cobra.NoArgs,
+	RunE: runMigrationCheck,
+}
+
+func init() {
+	migrationCmd.AddCommand(migrationCheckCmd)
+
+	migrationCheckCmd.Flags().StringVar(&migrationCheckBase, "base", "", "Git revision to compare against for out-of-order migrations (e.g. origin/main)")
+}
+
+func runMigrationCheck(cmd *cobra.Command, args []string) error {
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+
+	fmt.Printf("🔍 Checking migrations: %s\n", config.ProjectName)
+	fmt.Printf("   Versioning: %s\n", config.MigrationVersioning)
+
+	var baseFiles []string
+	if migrationCheckBase != "" {
+		fmt.Printf("   Base: %s\n", migrationCheckBase)
+		if baseFiles, err = gitMigrationFiles(config.OutputDir, migrationCheckBase); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+
+	result, err := generator.NewMigrationGenerator(config).Check(baseFiles)
+	if err != nil {
+		return err
+	}
+
+	printValidationResult(result)
+
+	if result.HasErrors() {
+		return fmt.Errorf("migration check failed with %d error(s)", result.ErrorCount())
+	}
+
+	return nil
+}
+
+// gitMigrationFiles lists the files in migrations/ at a git revision
+func gitMigrationFiles(dir, rev string) ([]string, error) {
+	c := exec.Command("git", "-C", dir, "ls-tree", "--name-only", rev, "migrations/")
+	out, err := c.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to list migrations at %s: %s", rev, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to list migrations at %s: %w", rev, err)
+	}
+
+	files := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, path.Base(line))
+		}
+	}
+	return files, nil
+}
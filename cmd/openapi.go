@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openapiOutput    string
+	openapiTitle     string
+	openapiVersion   string
+	openapiBasePath  string
+	openapiSwaggerUI bool
+)
+
+// openapiCmd represents the openapi parent command
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "OpenAPI documentation for the HTTP adapter",
+	Long:  `Generate OpenAPI 3 documentation from the HTTP handler packages of the project.`,
+}
+
+// openapiGenerateCmd builds openapi.yaml from the handler packages
+var openapiGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate an OpenAPI 3 spec from the HTTP handlers",
+	Long: `Generate an OpenAPI 3 spec from the HTTP handler packages in
+internal/adapters/<inbound>/http/<entities>/.
+
+  - Paths and methods come from the routes registered in Configure
+    (chi Route blocks, echo/gin/fiber groups, stdlib "METHOD /path" patterns)
+  - Request and response schemas come from the DTO structs and their json tags
+  - DTOs that still contain a TODO are completed with the domain entity fields
+    found by semantic analysis
+
+With --swagger-ui, a docs handler package serving Swagger UI and the spec is
+generated in the HTTP adapter. Later runs keep its copy of the spec in sync.
+
+Example:
+  hexago openapi generate
+  hexago openapi generate --output docs/openapi.yaml --title "Shop API" --api-version 1.2.0
+  hexago openapi generate --swagger-ui`,
+	Args: cobra.NoArgs,
+	RunE: runOpenAPIGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(openapiCmd)
+	openapiCmd.AddCommand(openapiGenerateCmd)
+
+	openapiGenerateCmd.Flags().StringVarP(&openapiOutput, "output", "o", filepath.Join("api", "openapi.yaml"), "Spec file, relative to the project root")
+	openapiGenerateCmd.Flags().StringVar(&openapiTitle, "title", "", "API title (defaults to the project name)")
+	openapiGenerateCmd.Flags().StringVar(&openapiVersion, "api-version", "0.1.0", "API version")
+	openapiGenerateCmd.Flags().StringVar(&openapiBasePath, "base-path", "/api/v1", "Path the handlers are mounted on")
+	openapiGenerateCmd.Flags().BoolVar(&openapiSwaggerUI, "swagger-ui", false, "Generate a handler serving Swagger UI and the spec")
+}
+
+func runOpenAPIGenerate(cmd *cobra.Command, args []string) error {
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	title := openapiTitle
+	if title == "" {
+		title = config.ProjectName + " API"
+	}
+
+	fmt.Printf("📖 Generating OpenAPI spec: %s\n", openapiOutput)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	// Domain structs complete the scaffolded DTOs; the spec is still usable without them
+	var structs []analyzer.DomainStruct
+	if pkgs, err := analyzer.LoadProject(workingDir); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to load project for semantic analysis: %v\n", err)
+		fmt.Fprintf(os.Stderr, "🔄 Falling back to the DTO fields only\n")
+	} else {
+		structs = analyzer.FindDomainStructs(pkgs)
+	}
+
+	gen := generator.NewOpenAPIGenerator(config)
+	operations, err := gen.Generate(generator.OpenAPIOptions{
+		Output:    openapiOutput,
+		Title:     title,
+		Version:   openapiVersion,
+		BasePath:  openapiBasePath,
+		SwaggerUI: openapiSwaggerUI,
+	}, structs)
+	if err != nil {
+		return fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Printf("\n✅ OpenAPI spec generated with %d operation(s)\n", operations)
+	if openapiSwaggerUI {
+		fmt.Printf("\n📝 Next steps:\n")
+		fmt.Printf("  1. Register the docs handler in internal/adapters/%s/http/http.go:\n", config.AdapterInboundDir())
+		fmt.Printf("       srv.Use(docs.New(&docs.Config{\n")
+		fmt.Printf("           Path: \"/docs\",\n")
+		fmt.Printf("           %s,\n", generator.SwaggerUIRouterField(config.Framework))
+		fmt.Printf("       }))\n")
+		fmt.Printf("  2. Open http://localhost:8080/docs\n")
+	}
+
+	return nil
+}
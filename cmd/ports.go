@@ -0,0 +1,112 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portsFormat string
+)
+
+// portsCmd represents the ports command
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "Show which adapters implement the ports of the core",
+	Long: `Report, for every port of the core, the project types implementing it.
+
+Ports are the exported interfaces declared in internal/core. Using the
+type information of the project, ports lists the types implementing each
+one, and warns about:
+  ⚠️  port-implementations  ports no type implements
+  ⚠️  adapter-ports         outbound adapter types implementing no port
+  ⚠️  port-assertions       adapter types implementing a port without a
+                           compile-time assertion: var _ Port = (*Impl)(nil)
+
+Output formats (--format):
+  text   Coverage matrix and warnings (default)
+  json   Ports, implementations and warnings, for scripts
+
+Example:
+  hexago ports
+  hexago ports --format json`,
+	Args: cobra.NoArgs,
+	RunE: runPorts,
+}
+
+func init() {
+	rootCmd.AddCommand(portsCmd)
+
+	portsCmd.Flags().StringVar(&portsFormat, "format", "text", "Output format: text, json")
+}
+
+func runPorts(cmd *cobra.Command, args []string) error {
+	if portsFormat != "text" && portsFormat != "json" {
+		return fmt.Errorf("invalid format %q: use text or json", portsFormat)
+	}
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+
+	pkgs, err := analyzer.LoadSyntax(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	report, err := generator.Ports(config, pkgs)
+	if err != nil {
+		return err
+	}
+
+	if portsFormat == "json" {
+		return report.WriteJSON(os.Stdout)
+	}
+
+	fmt.Printf("🔌 Ports of %s: %d\n", config.ProjectName, len(report.Ports))
+
+	implemented := 0
+	dir := ""
+	for _, port := range report.Ports {
+		i := strings.LastIndex(port.Port, ".")
+		if port.Port[:i] != dir {
+			dir = port.Port[:i]
+			fmt.Printf("\n   %s\n", dir)
+		}
+		fmt.Printf("     %s\n", port.Port[i+1:])
+
+		if len(port.Implementations) == 0 {
+			fmt.Println("       ✗ no implementation")
+			continue
+		}
+		implemented++
+		for _, impl := range port.Implementations {
+			if impl.Adapter && !impl.Asserted {
+				fmt.Printf("       ⚠️  %s (no compile-time assertion)\n", impl.Type)
+				continue
+			}
+			fmt.Printf("       ✓ %s\n", impl.Type)
+		}
+	}
+
+	if len(report.Violations) > 0 {
+		fmt.Println()
+		for _, violation := range report.Violations {
+			fmt.Printf("⚠️  %s\n", violation)
+		}
+	}
+
+	fmt.Printf("\n📊 %d port(s), %d implemented, %d warning(s)\n",
+		len(report.Ports), implemented, len(report.Violations))
+
+	return nil
+}
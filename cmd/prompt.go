@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// prompter asks questions on out and reads the answers from in.
+// Invalid answers are reported and the question is asked again.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// newPrompter creates a prompter reading from in and writing to out
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// isTerminal reports whether stdin is an interactive terminal
+func isTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// readLine reads one trimmed line; io.EOF is returned only when nothing was read
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// String asks for free text. An empty answer selects def; validate may be nil.
+func (p *prompter) String(label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "? %s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(p.out, "? %s: ", label)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", fmt.Errorf("failed to read answer: %w", err)
+		}
+		if answer == "" {
+			answer = def
+		}
+
+		if answer == "" {
+			fmt.Fprintln(p.out, "  ⚠️  A value is required")
+			continue
+		}
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				fmt.Fprintf(p.out, "  ⚠️  %v\n", err)
+				continue
+			}
+		}
+
+		return answer, nil
+	}
+}
+
+// Choice asks to pick one of options, by name or by number. An empty answer selects def.
+func (p *prompter) Choice(label string, options []string, def string) (string, error) {
+	fmt.Fprintf(p.out, "? %s\n", label)
+	for i, option := range options {
+		marker := " "
+		if option == def {
+			marker = "*"
+		}
+		fmt.Fprintf(p.out, "  %s %d) %s\n", marker, i+1, option)
+	}
+
+	answer, err := p.String("Choose", def, func(answer string) error {
+		if matchChoice(answer, options) == "" {
+			return fmt.Errorf("invalid choice '%s'. Valid options: %s", answer, strings.Join(options, ", "))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return matchChoice(answer, options), nil
+}
+
+// matchChoice resolves answer (an option or its 1-based number) to an option, or ""
+func matchChoice(answer string, options []string) string {
+	for i, option := range options {
+		if answer == option || answer == fmt.Sprint(i+1) {
+			return option
+		}
+	}
+	return ""
+}
+
+// Confirm asks a yes/no question. An empty answer selects def.
+func (p *prompter) Confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(p.out, "? %s (%s): ", label, hint)
+
+		answer, err := p.readLine()
+		if err != nil {
+			return false, fmt.Errorf("failed to read answer: %w", err)
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "  ⚠️  Please answer y or n")
+	}
+}
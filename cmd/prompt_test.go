@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPrompter(t *testing.T) {
+	options := []string{"http-server", "service"}
+
+	tests := []struct {
+		name  string
+		input string
+		ask   func(p *prompter) (any, error)
+		want  any
+	}{
+		{
+			name:  "string default",
+			input: "\n",
+			ask:   func(p *prompter) (any, error) { return p.String("Name", "my-app", nil) },
+			want:  "my-app",
+		},
+		{
+			name:  "string retries until valid",
+			input: "bad name\nmy-api\n",
+			ask:   func(p *prompter) (any, error) { return p.String("Name", "", validateProjectName) },
+			want:  "my-api",
+		},
+		{
+			name:  "choice by number",
+			input: "2\n",
+			ask:   func(p *prompter) (any, error) { return p.Choice("Type", options, "http-server") },
+			want:  "service",
+		},
+		{
+			name:  "choice retries until valid",
+			input: "3\nservice\n",
+			ask:   func(p *prompter) (any, error) { return p.Choice("Type", options, "http-server") },
+			want:  "service",
+		},
+		{
+			name:  "confirm default",
+			input: "\n",
+			ask:   func(p *prompter) (any, error) { return p.Confirm("Docker?", true) },
+			want:  true,
+		},
+		{
+			name:  "confirm retries until valid",
+			input: "maybe\nno\n",
+			ask:   func(p *prompter) (any, error) { return p.Confirm("Docker?", true) },
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ask(newPrompter(strings.NewReader(tt.input), io.Discard))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("end of input", func(t *testing.T) {
+		_, err := newPrompter(strings.NewReader(""), io.Discard).String("Name", "", nil)
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("got %v, want io.EOF", err)
+		}
+	})
+}
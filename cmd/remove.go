@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/spf13/cobra"
+)
+
+// removeCmd represents the remove command
+var removeCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove components scaffolded with hexago add",
+	Long: `Remove components from a project, the inverse of hexago add.
+
+Each subcommand deletes the files hexago add generated for the component
+(its sub-package, or its file and test), and drops them from the manifest
+in .hexago.yaml. Files that were edited since they were generated, or that
+hexago did not generate, are deleted too, with a warning.
+
+Packages that still import a deleted package are reported, so the
+remaining references can be cleaned up.
+
+Available subcommands:
+  service    - Remove a service/usecase and update services.go
+  domain     - Remove a domain entity or value object
+  adapter    - Remove a primary or secondary adapter
+  worker     - Remove a background worker
+  tool       - Remove an infrastructure tool
+  migration  - Remove a migration that was not applied yet
+
+Example:
+  hexago remove service Category
+  hexago remove domain entity Category
+  hexago remove adapter secondary database Users
+  hexago remove worker EmailWorker
+  hexago remove migration create_users_table`,
+}
+
+func init() {
+	rootCmd.AddCommand(removeCmd)
+}
+
+// loadImportGraph loads the imports of the project packages, to warn about
+// the ones still importing removed code. It returns nil when the project
+// cannot be loaded.
+func loadImportGraph() analyzer.ImportGraph {
+	graph, err := analyzer.LoadImportGraph(workingDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to load project for import analysis: %v\n", err)
+		fmt.Fprintf(os.Stderr, "🔄 Skipping the check for packages importing the removed code\n")
+		return nil
+	}
+	return graph
+}
+
+// printRemoveNextSteps prints the next steps after removing a component
+func printRemoveNextSteps() {
+	fmt.Printf("\n📝 Next steps:\n")
+	fmt.Printf("  1. Remove the remaining references to the deleted code (see the warnings above)\n")
+	fmt.Printf("  2. Run: go build ./... && go mod tidy\n")
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// removeAdapterCmd represents the remove adapter command
+var removeAdapterCmd = &cobra.Command{
+	Use:   "adapter",
+	Short: "Remove adapters (primary/secondary or driver/driven)",
+	Long: `Remove adapter implementations generated with hexago add adapter.
+
+Example:
+  hexago remove adapter primary http UserHandler
+  hexago remove adapter secondary database Users`,
+}
+
+// removeAdapterPrimaryCmd removes primary (inbound) adapters
+var removeAdapterPrimaryCmd = &cobra.Command{
+	Use:   "primary <type> <name>",
+	Short: "Remove a primary (inbound) adapter",
+	Long: `Remove a primary/driver adapter: its file and test, or the per-entity
+sub-package of http and grpc adapters generated with --entity.
+
+Removing a grpc service package also removes its .proto (api/proto/<pkg>),
+the code generated from it (gen/proto/<pkg>) and its registration in grpc.go.
+
+Types: http, grpc, queue, cli
+
+Example:
+  hexago remove adapter primary http UserHandler
+  hexago remove adapter primary http Category
+  hexago remove adapter primary grpc Order`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRemoveAdapterPrimary,
+}
+
+// removeAdapterSecondaryCmd removes secondary (outbound) adapters
+var removeAdapterSecondaryCmd = &cobra.Command{
+	Use:   "secondary <type> <name>",
+	Short: "Remove a secondary (outbound) adapter",
+	Long: `Remove a secondary/driven adapter: the sub-package of a database adapter,
+or the file and test of the other types. Migrations generated with the
+adapter are kept; remove them with hexago remove migration.
+
+Types: database, external, cache
+
+Example:
+  hexago remove adapter secondary database Users
+  hexago remove adapter secondary external EmailClient`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRemoveAdapterSecondary,
+}
+
+func init() {
+	removeCmd.AddCommand(removeAdapterCmd)
+	removeAdapterCmd.AddCommand(removeAdapterPrimaryCmd)
+	removeAdapterCmd.AddCommand(removeAdapterSecondaryCmd)
+}
+
+func runRemoveAdapterPrimary(cmd *cobra.Command, args []string) error {
+	return runRemoveAdapter("primary", args[0], args[1])
+}
+
+func runRemoveAdapterSecondary(cmd *cobra.Command, args []string) error {
+	return runRemoveAdapter("secondary", args[0], args[1])
+}
+
+// runRemoveAdapter removes an adapter in the given direction
+func runRemoveAdapter(direction, adapterType, adapterName string) error {
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing %s adapter: %s (%s)\n", direction, adapterName, adapterType)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewAdapterGenerator(config)
+	if direction == "primary" {
+		err = gen.RemovePrimary(adapterType, adapterName, loadImportGraph())
+	} else {
+		err = gen.RemoveSecondary(adapterType, adapterName, loadImportGraph())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove adapter: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Adapter removed successfully!")
+	printRemoveNextSteps()
+
+	return nil
+}
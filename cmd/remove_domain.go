@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	removeVOEntity string
+)
+
+// removeDomainCmd represents the remove domain command
+var removeDomainCmd = &cobra.Command{
+	Use:   "domain",
+	Short: "Remove domain entities or value objects",
+	Long: `Remove domain layer components from internal/core/domain.
+
+Example:
+  hexago remove domain entity User
+  hexago remove domain valueobject Email`,
+}
+
+// removeDomainEntityCmd represents removing a domain entity
+var removeDomainEntityCmd = &cobra.Command{
+	Use:   "entity <name>",
+	Short: "Remove a domain entity",
+	Long: `Remove the sub-package of a domain entity, with its port and the value
+objects co-located with it.
+
+Example:
+  hexago remove domain entity User`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRemoveDomainEntity,
+}
+
+// removeDomainValueObjectCmd represents removing a value object
+var removeDomainValueObjectCmd = &cobra.Command{
+	Use:   "valueobject <name>",
+	Short: "Remove a value object",
+	Long: `Remove a value object: its own sub-package, or its file and test from the
+entity sub-package with --entity.
+
+Example:
+  hexago remove domain valueobject Email
+  hexago remove domain valueobject Address --entity User`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRemoveDomainValueObject,
+}
+
+func init() {
+	removeCmd.AddCommand(removeDomainCmd)
+	removeDomainCmd.AddCommand(removeDomainEntityCmd)
+	removeDomainCmd.AddCommand(removeDomainValueObjectCmd)
+
+	removeDomainValueObjectCmd.Flags().StringVarP(&removeVOEntity, "entity", "e", "", "Entity the value object is co-located with")
+}
+
+func runRemoveDomainEntity(cmd *cobra.Command, args []string) error {
+	entityName := args[0]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing domain entity: %s\n", entityName)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewDomainGenerator(config)
+	if err := gen.RemoveEntity(entityName, loadImportGraph()); err != nil {
+		return fmt.Errorf("failed to remove entity: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Entity removed successfully!")
+	printRemoveNextSteps()
+
+	return nil
+}
+
+func runRemoveDomainValueObject(cmd *cobra.Command, args []string) error {
+	voName := args[0]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing value object: %s\n", voName)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewDomainGenerator(config)
+	if err := gen.RemoveValueObject(voName, removeVOEntity, loadImportGraph()); err != nil {
+		return fmt.Errorf("failed to remove value object: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Value object removed successfully!")
+	printRemoveNextSteps()
+
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// removeMigrationCmd represents the remove migration command
+var removeMigrationCmd = &cobra.Command{
+	Use:   "migration <name|version>",
+	Short: "Remove a migration that was not applied yet",
+	Long: `Remove the files of a migration from migrations/: the up and down SQL
+files, or the Go file of a Go migration.
+
+Only remove migrations that were never applied to a database; undo applied
+ones with a new migration instead.
+
+Example:
+  hexago remove migration create_users_table
+  hexago remove migration 3
+  hexago remove migration 20260314153000`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRemoveMigration,
+}
+
+func init() {
+	removeCmd.AddCommand(removeMigrationCmd)
+}
+
+func runRemoveMigration(cmd *cobra.Command, args []string) error {
+	migration := args[0]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing migration: %s\n", migration)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewMigrationGenerator(config)
+	if err := gen.Remove(migration); err != nil {
+		return fmt.Errorf("failed to remove migration: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Migration removed successfully!")
+	fmt.Printf("\n📝 Next steps:\n")
+	fmt.Printf("  1. Make sure no database applied the migration\n")
+	fmt.Printf("  2. Run: hexago migration check\n")
+
+	return nil
+}
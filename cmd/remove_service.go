@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// removeServiceCmd represents the remove service command
+var removeServiceCmd = &cobra.Command{
+	Use:   "service <name>",
+	Short: "Remove a service/usecase from the project",
+	Long: `Remove a service (or usecase) sub-package from internal/core.
+
+The service is looked up by its lowercase name, or by the plural used for
+services generated with --entity (Category → categories). The services
+aggregator (services.go) is regenerated without it.
+
+Example:
+  hexago remove service Category
+  hexago remove service CreateUser`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRemoveService,
+}
+
+func init() {
+	removeCmd.AddCommand(removeServiceCmd)
+}
+
+func runRemoveService(cmd *cobra.Command, args []string) error {
+	serviceName := args[0]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w\nMake sure you're in a hexagonal architecture project directory", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing service: %s\n", serviceName)
+	fmt.Printf("   Project: %s\n", config.ProjectName)
+	fmt.Printf("   Logic dir: %s\n\n", config.CoreLogic)
+
+	gen := generator.NewServiceGenerator(config)
+	if err := gen.Remove(serviceName, loadImportGraph()); err != nil {
+		return fmt.Errorf("failed to remove service: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Service removed successfully!")
+	printRemoveNextSteps()
+
+	return nil
+}
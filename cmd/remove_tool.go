@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// removeToolCmd represents the remove tool command
+var removeToolCmd = &cobra.Command{
+	Use:   "tool <type> <name>",
+	Short: "Remove an infrastructure tool",
+	Long: `Remove a tool and its test from internal/infrastructure/<type>.
+
+Tool types: logger, validator, mapper, middleware
+
+Example:
+  hexago remove tool middleware AuthMiddleware`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRemoveTool,
+}
+
+func init() {
+	removeCmd.AddCommand(removeToolCmd)
+}
+
+func runRemoveTool(cmd *cobra.Command, args []string) error {
+	toolType := args[0]
+	toolName := args[1]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing %s tool: %s\n", toolType, toolName)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewToolGenerator(config)
+	if err := gen.Remove(toolType, toolName); err != nil {
+		return fmt.Errorf("failed to remove tool: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Tool removed successfully!")
+	printRemoveNextSteps()
+
+	return nil
+}
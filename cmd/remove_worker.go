@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// removeWorkerCmd represents the remove worker command
+var removeWorkerCmd = &cobra.Command{
+	Use:   "worker <name>",
+	Short: "Remove a background worker",
+	Long: `Remove a worker and its test from internal/workers.
+
+Example:
+  hexago remove worker EmailWorker`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRemoveWorker,
+}
+
+func init() {
+	removeCmd.AddCommand(removeWorkerCmd)
+}
+
+func runRemoveWorker(cmd *cobra.Command, args []string) error {
+	workerName := args[0]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("🗑️  Removing worker: %s\n", workerName)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	gen := generator.NewWorkerGenerator(config)
+	if err := gen.Remove(workerName); err != nil {
+		return fmt.Errorf("failed to remove worker: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Worker removed successfully!")
+	printRemoveNextSteps()
+
+	return nil
+}
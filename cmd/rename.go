@@ -0,0 +1,31 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename components across the layers of a project",
+	Long: `Rename components scaffolded with hexago add, across every layer they
+were generated in.
+
+Renames use the type information of the project: identifiers are renamed
+along with every use of them, and packages move to new directories with
+their imports updated. String literals (routes, SQL, messages) are left
+alone.
+
+Available subcommands:
+  entity     - Rename a domain entity and the packages built around it
+
+Example:
+  hexago rename entity Category ProductCategory`,
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/padiazg/hexago/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// renameEntityCmd represents the rename entity command
+var renameEntityCmd = &cobra.Command{
+	Use:   "entity <old-name> <new-name>",
+	Short: "Rename a domain entity across core and adapters",
+	Long: `Rename a domain entity and the sub-packages hexago generates for it:
+
+  internal/core/domain/<entities>/
+  internal/core/<services|usecases>/<entities>/
+  internal/adapters/<primary|driver>/http/<entities>/
+  internal/adapters/<primary|driver>/grpc/<entities>/
+  internal/adapters/<secondary|driven>/database/<entities>/
+
+The packages move to the directory of the new name, and the identifiers
+derived from the entity name (Category, NewCategory, CategoryRepository,
+categoriesDomain, ...) are renamed along with their uses in the whole
+project. services.go is rebuilt.
+
+Table names, routes and .proto packages are not renamed: they are part of
+the contracts of the application. Rename them with a migration or a new
+API version.
+
+Example:
+  hexago rename entity Category ProductCategory`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameEntity,
+}
+
+func init() {
+	renameCmd.AddCommand(renameEntityCmd)
+}
+
+func runRenameEntity(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("✏️  Renaming entity: %s → %s\n", oldName, newName)
+	fmt.Printf("   Project: %s\n\n", config.ProjectName)
+
+	pkgs, err := analyzer.LoadSyntax(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	gen := generator.NewDomainGenerator(config)
+	if err := gen.RenameEntity(oldName, newName, pkgs); err != nil {
+		return fmt.Errorf("failed to rename entity: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	fmt.Println("\n✅ Entity renamed successfully!")
+	fmt.Printf("\n📝 Next steps:\n")
+	fmt.Printf("  1. Run: go build ./... && go test ./...\n")
+	fmt.Printf("  2. Rename the table with a migration if needed: hexago add migration rename_%s_table\n", utils.ToPlural(utils.ToSnakeCase(oldName)))
+
+	return nil
+}
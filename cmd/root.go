@@ -4,10 +4,16 @@ Copyright © 2026 HexaGo Contributors
 package cmd
 
 import (
+	"os"
+
+	"github.com/padiazg/hexago/internal/generator"
 	"github.com/spf13/cobra"
 )
 
-var workingDir string
+var (
+	workingDir string
+	dryRun     bool
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "hexago",
@@ -43,4 +49,16 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().StringVarP(&workingDir, "working-directory", "w", "", "Working directory (defaults to current directory)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Show the files that would be created or changed without writing them")
+}
+
+// printDryRun prints the planned changes when --dry-run is set and reports
+// whether the caller should stop before printing its success message.
+func printDryRun(config *generator.ProjectConfig) bool {
+	if !config.DryRun {
+		return false
+	}
+
+	config.PrintDryRun(os.Stdout)
+	return true
 }
@@ -0,0 +1,69 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusAll bool
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which generated files were modified or deleted",
+	Long: `List the files hexago generated that were edited by hand or deleted since.
+
+hexago init and the add commands record every file they render in the
+generated section of .hexago.yaml: its path, the template and template
+source that produced it, and a hash of the content. Status compares the
+project files with that manifest.
+
+Example:
+  hexago status
+  hexago status --all   # also list the untouched files`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().BoolVarP(&statusAll, "all", "a", false, "Also list the untouched files")
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+
+	statuses, err := generator.Status(config)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[generator.FileState]int)
+	for _, s := range statuses {
+		counts[s.State]++
+	}
+
+	fmt.Printf("📋 Generated files of %s: %d\n", config.ProjectName, len(statuses))
+	for _, s := range statuses {
+		if s.State == generator.FileUntouched && !statusAll {
+			continue
+		}
+		fmt.Printf("   %-10s %s\n", s.State+":", s.Path)
+	}
+
+	fmt.Printf("\n   %d untouched, %d modified, %d deleted\n",
+		counts[generator.FileUntouched], counts[generator.FileModified], counts[generator.FileDeleted])
+
+	return nil
+}
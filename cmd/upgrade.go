@@ -0,0 +1,108 @@
+/*
+Copyright © 2026 HexaGo Contributors
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/padiazg/hexago/internal/generator"
+	"github.com/padiazg/hexago/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// upgradeCmd represents the upgrade command
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Re-apply the templates of this hexago version to a project",
+	Long: `Bring the improvements of newer hexago templates (cmd/run.go, the HTTP
+adapter, config.go, Makefile, ...) into a project generated by 'hexago init'.
+
+hexago init records the hexago version and a hash of every file it renders
+in .hexago.yaml, and keeps the rendered content in .hexago/base/. Upgrade
+renders the templates again with the project settings and, for each file:
+
+  - replaces it when you haven't edited it
+  - three-way merges the new output with your edits otherwise, using the
+    recorded content as the common ancestor
+  - marks conflicting regions in the file, git style:
+      <<<<<<< yours / ||||||| hexago <old> / ======= / >>>>>>> hexago <new>
+
+Files you deleted are left deleted. Projects generated before the record
+existed are merged against the lines both versions share, so review the
+result carefully. Commit .hexago/base/ with the rest of the project.
+
+The command exits with an error when conflicts are left to resolve.
+
+Example:
+  hexago upgrade
+  hexago upgrade --dry-run
+  hexago upgrade -w ./services/billing`,
+	Args: cobra.NoArgs,
+	RunE: runUpgrade,
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	config, err := generator.GetCurrentProjectConfig(workingDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect project: %w", err)
+	}
+	config.DryRun = dryRun
+
+	fmt.Printf("⬆️  Upgrading project: %s\n", config.ProjectName)
+	fmt.Printf("   hexago: %s\n\n", version.CurrentVersion().Version)
+
+	result, err := generator.NewProjectGenerator(config).Upgrade()
+	if err != nil {
+		return fmt.Errorf("failed to upgrade project: %w", err)
+	}
+
+	if printDryRun(config) {
+		return nil
+	}
+
+	if !result.Changed() {
+		fmt.Printf("\n✅ Project is up to date (generated by hexago %s)\n", result.FromVersion)
+		return nil
+	}
+
+	fmt.Printf("\n📋 Upgrade from hexago %s:\n", result.FromVersion)
+	printUpgradeFiles("Updated", result.Updated)
+	printUpgradeFiles("Merged", result.Merged)
+	printUpgradeFiles("Conflicts", result.Conflicts)
+	printUpgradeFiles("Created", result.Created)
+	printUpgradeFiles("Skipped (deleted)", result.Skipped)
+	printUpgradeFiles("No longer generated (left in place)", result.Dropped)
+	fmt.Printf("   Unchanged: %d\n", result.Unchanged)
+
+	fmt.Printf("\n📝 Next steps:\n")
+	step := 1
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("  %d. Resolve the conflict markers in the files listed above\n", step)
+		step++
+	}
+	fmt.Printf("  %d. Review the changes (git diff) and run:\n", step)
+	fmt.Printf("     go mod tidy && go build ./...\n")
+	fmt.Printf("  %d. Commit the changes together with .hexago.yaml and .hexago/base/\n", step+1)
+
+	if len(result.Conflicts) > 0 {
+		return fmt.Errorf("upgrade left conflicts in %d file(s)", len(result.Conflicts))
+	}
+
+	return nil
+}
+
+// printUpgradeFiles prints one group of files touched by the upgrade
+func printUpgradeFiles(label string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	fmt.Printf("   %s:\n", label)
+	for _, f := range files {
+		fmt.Printf("     - %s\n", f)
+	}
+}
@@ -5,13 +5,19 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
 
+	"github.com/padiazg/hexago/internal/analyzer"
 	"github.com/padiazg/hexago/internal/generator"
+	"github.com/padiazg/hexago/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 var (
-	validateFix bool
+	validateFix    bool
+	validateYes    bool
+	validateFormat string
 )
 
 // validateCmd represents the validate command
@@ -21,28 +27,76 @@ var validateCmd = &cobra.Command{
 	Long: `Validate that the project follows hexagonal architecture principles.
 
 Checks performed:
-  ✓ Core domain has no external dependencies
-  ✓ Services/UseCases only depend on domain and ports
-  ✓ Adapters don't import from other adapters
   ✓ Proper package organization
+  ✓ Layer dependencies, against the architecture rules of .hexago.yaml
   ✓ Naming conventions
-  ✓ Dependency direction (inward only)
+
+Without an architecture section in .hexago.yaml the default rules apply:
+  ✓ Core domain has no external dependencies
+  ✓ Core domain and ports only use the standard library, except
+    database/sql, net/http and os/exec
+  ✓ Services/UseCases don't import adapters
+  ✓ Services/UseCases don't use database/sql, net/http or os/exec (warning)
+  ✓ Inbound and outbound adapters don't import each other (warning)
+
+Rules restrict which layers a layer may import, and which packages of
+other modules and the standard library ("std"):
+
+  architecture:
+    rules:
+      - id: domain-isolation
+        layer: domain
+        may_import: [pkg]
+        severity: error
+      - id: domain-packages
+        layer: domain
+        allow_external: [std, github.com/google/uuid]
+        deny_external: [database/sql, net/http]
+
+Output formats (--format):
+  text   Human-readable report (default)
+  json   Checks, violations and a summary, for scripts
+  sarif  SARIF 2.1.0 log, for code scanning annotations
+  junit  JUnit XML report, for CI test reports
+
+Every violation carries its rule ID, severity, file, line and column.
+The command exits with an error when there are errors, whatever the format.
+
+Fixes (--fix):
+  ✓ Create missing required directories
+  ✓ Rename adapter directories to the adapter style of .hexago.yaml
+    (driver ↔ primary, driven ↔ secondary), updating the imports
+  ✓ Replace adapter types used by services/use cases with port
+    interfaces in the domain package, and the adapter import with it
+
+Every fix is shown as a diff and applied only once confirmed (--yes
+applies them all). With --dry-run the fixes are only shown.
 
 Example:
   hexago validate
-  hexago validate --fix  # Attempt to fix issues (future)`,
+  hexago validate --format sarif > hexago.sarif
+  hexago validate --fix
+  hexago validate --fix --dry-run`,
 	RunE: runValidate,
 }
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
 
-	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "Attempt to fix issues automatically (not yet implemented)")
+	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "Fix violations, confirming each fix")
+	validateCmd.Flags().BoolVarP(&validateYes, "yes", "y", false, "Apply fixes without confirmation")
+	validateCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text, json, sarif, junit")
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
-	if validateFix {
-		return fmt.Errorf("--fix flag not yet implemented")
+	if validateFormat != "text" && !slices.Contains(generator.ValidationFormats, validateFormat) {
+		return fmt.Errorf("invalid format %q: use text, json, sarif or junit", validateFormat)
+	}
+	if validateFix && validateFormat != "text" {
+		return fmt.Errorf("--fix only supports the text format")
+	}
+	if validateFix && !validateYes && !dryRun && !isTerminal() {
+		return fmt.Errorf("--fix confirms every fix on a terminal: use --yes to apply them all")
 	}
 
 	config, err := generator.GetCurrentProjectConfig(workingDir)
@@ -50,6 +104,26 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to detect project: %w\nMake sure you're in a hexagonal architecture project directory", err)
 	}
 
+	if validateFix {
+		if err := fixViolations(config); err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+	}
+
+	if validateFormat != "text" {
+		result := generator.NewValidator(config).Validate()
+		if err := result.WriteReport(os.Stdout, validateFormat, config.ProjectName, version.CurrentVersion().Version); err != nil {
+			return err
+		}
+		if result.HasErrors() {
+			return fmt.Errorf("validation failed with %d error(s)", result.ErrorCount())
+		}
+		return nil
+	}
+
 	fmt.Printf("🔍 Validating project: %s\n", config.ProjectName)
 	fmt.Printf("   Module: %s\n", config.ModuleName)
 	fmt.Printf("   Adapter style: %s\n", config.AdapterStyle)
@@ -70,26 +144,104 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// fixViolations offers the fixes of the violations of the project one at a
+// time, planning them again after each fix applied. In dry-run mode every
+// fix is only shown.
+func fixViolations(config *generator.ProjectConfig) error {
+	fmt.Printf("🔧 Fixing project: %s\n", config.ProjectName)
+
+	fixer := generator.NewFixer(config)
+	prompt := newPrompter(os.Stdin, os.Stdout)
+	done := make(map[string]bool) // applied or declined, by description
+	applied := 0
+
+	var notes []string
+	for {
+		pkgs, err := analyzer.LoadSyntax(workingDir)
+		if err != nil {
+			return fmt.Errorf("failed to load project: %w", err)
+		}
+
+		var fixes []generator.Fix
+		fixes, notes = fixer.Plan(pkgs)
+		fixes = slices.DeleteFunc(fixes, func(fix generator.Fix) bool { return done[fix.Description] })
+		if len(fixes) == 0 {
+			break
+		}
+
+		if dryRun {
+			for _, fix := range fixes {
+				fmt.Printf("\n🔧 %s [%s]\n", fix.Description, fix.Rule)
+				if err := fixer.Preview(os.Stdout, fix); err != nil {
+					fmt.Printf("⚠️  Warning: %v\n", err)
+				}
+			}
+			break
+		}
+
+		fix := fixes[0]
+		done[fix.Description] = true
+		fmt.Printf("\n🔧 %s [%s]\n", fix.Description, fix.Rule)
+		if err := fixer.Preview(os.Stdout, fix); err != nil {
+			fmt.Printf("⚠️  Warning: cannot fix: %v\n", err)
+			continue
+		}
+
+		if !validateYes {
+			fmt.Println()
+			ok, err := prompt.Confirm("Apply this fix?", false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("   Skipped")
+				continue
+			}
+		}
+
+		if err := fixer.Apply(fix); err != nil {
+			return fmt.Errorf("failed to apply fix: %w", err)
+		}
+		applied++
+	}
+
+	if len(notes) > 0 {
+		fmt.Println("\n⚠️  Not fixed automatically:")
+		for _, note := range notes {
+			fmt.Printf("   - %s\n", note)
+		}
+	}
+
+	if dryRun {
+		fmt.Println("\n🔎 Dry run — no files were written")
+		return nil
+	}
+	fmt.Printf("\n✅ %d fix(es) applied\n\n", applied)
+	return nil
+}
+
 func printValidationResult(result *generator.ValidationResult) {
 	fmt.Println("📋 Validation Results:")
 
 	// Print successes
 	for _, check := range result.Successes {
-		fmt.Printf("✓ %s\n", check)
+		fmt.Printf("✓ %s\n", check.Message)
 	}
 
 	// Print warnings
-	if len(result.Warnings) > 0 {
+	warnings := result.Warnings()
+	if len(warnings) > 0 {
 		fmt.Println()
-		for _, warning := range result.Warnings {
+		for _, warning := range warnings {
 			fmt.Printf("⚠️  %s\n", warning)
 		}
 	}
 
 	// Print errors
-	if len(result.Errors) > 0 {
+	errors := result.Errors()
+	if len(errors) > 0 {
 		fmt.Println()
-		for _, err := range result.Errors {
+		for _, err := range errors {
 			fmt.Printf("✗ %s\n", err)
 		}
 	}
@@ -97,12 +249,12 @@ func printValidationResult(result *generator.ValidationResult) {
 	// Summary
 	fmt.Printf("\n📊 Summary:\n")
 	fmt.Printf("   ✓ Passed: %d\n", len(result.Successes))
-	fmt.Printf("   ⚠️  Warnings: %d\n", len(result.Warnings))
-	fmt.Printf("   ✗ Errors: %d\n", len(result.Errors))
+	fmt.Printf("   ⚠️  Warnings: %d\n", len(warnings))
+	fmt.Printf("   ✗ Errors: %d\n", len(errors))
 
 	if result.HasErrors() {
 		fmt.Printf("\n❌ Validation FAILED\n")
-	} else if len(result.Warnings) > 0 {
+	} else if len(warnings) > 0 {
 		fmt.Printf("\n⚠️  Validation passed with warnings\n")
 	} else {
 		fmt.Printf("\n✅ Validation PASSED\n")
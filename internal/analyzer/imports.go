@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/build"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ImportGraph maps the import path of each package imported in a project to
+// the project packages importing it
+type ImportGraph map[string][]string
+
+// LoadImportGraph loads the imports of every package in a project directory,
+// test files included. Packages that don't compile are kept: their imports
+// are read from the source.
+func LoadImportGraph(dir string) (ImportGraph, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedImports,
+		Dir:   dir,
+		Tests: true,
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	graph := make(ImportGraph)
+	for _, pkg := range pkgs {
+		// Skip the generated test main packages
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		importer := strings.TrimSuffix(pkg.PkgPath, "_test")
+		for path := range pkg.Imports {
+			if !slices.Contains(graph[path], importer) {
+				graph[path] = append(graph[path], importer)
+			}
+		}
+	}
+
+	for path := range graph {
+		slices.Sort(graph[path])
+	}
+
+	return graph, nil
+}
+
+// Importers returns the packages importing importPath, sorted
+func (g ImportGraph) Importers(importPath string) []string {
+	return g[importPath]
+}
+
+// StandardPackages returns the import paths of the packages of the standard
+// library: the directories with Go files in $GOROOT/src, commands and
+// vendored packages excluded. They are read once, without the go command.
+func StandardPackages() (map[string]bool, error) {
+	return standardPackages()
+}
+
+var standardPackages = sync.OnceValues(func() (map[string]bool, error) {
+	if build.Default.GOROOT == "" {
+		return nil, fmt.Errorf("GOROOT not found: set the GOROOT environment variable")
+	}
+	src := filepath.Join(build.Default.GOROOT, "src")
+
+	std := make(map[string]bool)
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path == filepath.Join(src, "cmd") || name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if dir := filepath.Dir(path); dir != src && strings.HasSuffix(path, ".go") {
+			rel, _ := filepath.Rel(src, dir)
+			std[filepath.ToSlash(rel)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the standard library: %w", err)
+	}
+	return std, nil
+})
@@ -0,0 +1,30 @@
+package analyzer
+
+import "testing"
+
+func TestStandardPackages(t *testing.T) {
+	std, err := StandardPackages()
+	if err != nil {
+		t.Fatalf("StandardPackages() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"fmt", true},
+		{"net/http", true},
+		{"encoding/json", true},
+		{"net/httpx", false},
+		{"cmd/go", false},
+		{"vendor/golang.org/x/net/http2/hpack", false},
+		{"golang.org/x/net/http2/hpack", false},
+		{"example.com/demo", false},
+	}
+
+	for _, tt := range tests {
+		if got := std[tt.path]; got != tt.want {
+			t.Errorf("StandardPackages()[%s] = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -53,6 +53,53 @@ func FindInterfaceByName(pkgs []*packages.Package, name string) (*PortInfo, erro
 	return nil, fmt.Errorf("interface %q not found", name)
 }
 
+// FindMethodsByName returns the exported method set of a concrete named type
+// (e.g. a core service struct) as a PortInfo, so it can be used like a port.
+func FindMethodsByName(pkgs []*packages.Package, name string) (*PortInfo, error) {
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+
+		obj := pkg.Types.Scope().Lookup(name)
+		if obj == nil {
+			continue
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, isIface := named.Underlying().(*types.Interface); isIface {
+			continue
+		}
+
+		mset := types.NewMethodSet(types.NewPointer(named))
+		methods := make([]MethodInfo, 0, mset.Len())
+		for i := 0; i < mset.Len(); i++ {
+			fn := mset.At(i).Obj()
+			if !fn.Exported() {
+				continue
+			}
+			sig := fn.Type().(*types.Signature)
+			methods = append(methods, MethodInfo{
+				Name:    fn.Name(),
+				Params:  extractParams(sig.Params()),
+				Returns: extractParams(sig.Results()),
+			})
+		}
+
+		return &PortInfo{
+			Name:       name,
+			Package:    pkg.Name,
+			ImportPath: pkg.PkgPath,
+			Methods:    methods,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("type %q not found", name)
+}
+
 // extractPortInfo converts a types.Interface to PortInfo.
 func extractPortInfo(pkg *packages.Package, name string, iface *types.Interface) PortInfo {
 	methods := make([]MethodInfo, 0, iface.NumMethods())
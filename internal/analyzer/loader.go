@@ -39,6 +39,37 @@ func LoadProject(dir string) ([]*packages.Package, error) {
 	return valid, nil
 }
 
+// LoadProjectPartial loads the packages of ./internal/core/... like
+// LoadProject, keeping the ones with type errors: their syntax and the
+// declarations that did type-check are enough to look types up.
+func LoadProjectPartial(dir string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedTypes |
+			packages.NeedSyntax |
+			packages.NeedImports |
+			packages.NeedName,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(cfg, "./internal/core/...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	var loaded []*packages.Package
+	for _, pkg := range pkgs {
+		if pkg.Types != nil {
+			loaded = append(loaded, pkg)
+		}
+	}
+
+	if len(loaded) == 0 {
+		return nil, fmt.Errorf("no packages found in %s/internal/core", dir)
+	}
+
+	return loaded, nil
+}
+
 // LoadSinglePackage loads a single package by import path.
 func LoadSinglePackage(dir, importPath string) (*packages.Package, error) {
 	cfg := &packages.Config{
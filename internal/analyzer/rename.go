@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TextEdit replaces the bytes [Start, End) of a file with New
+type TextEdit struct {
+	Start int
+	End   int
+	New   string
+}
+
+// LoadSyntax loads every package in a project directory, test files
+// included, with the syntax trees and type information needed to rewrite
+// them. Packages with type errors are kept: their information is partial.
+func LoadSyntax(dir string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedImports |
+			packages.NeedTypes |
+			packages.NeedSyntax |
+			packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages found in %s", dir)
+	}
+
+	return pkgs, nil
+}
+
+// RenameEdits computes the edits that move packages to new import paths and
+// rename identifiers, keyed by absolute file name.
+//
+// moves maps the import path of each moved package to its new one. The
+// identifiers declared in the moved packages and in the scope packages are
+// renamed with rename, along with every use of them, the package clauses of
+// the moved packages and the names they are imported as. Comments in the
+// moved packages mentioning a renamed declaration follow it; string
+// literals are left alone.
+func RenameEdits(pkgs []*packages.Package, moves map[string]string, scope []string, rename func(string) string) map[string][]TextEdit {
+	owned := func(path string) bool {
+		path = strings.TrimSuffix(path, "_test")
+		_, moved := moves[path]
+		return moved || slices.Contains(scope, path)
+	}
+
+	edits := make(map[string]map[int]TextEdit)
+	add := func(fset *token.FileSet, pos token.Pos, old, name string) {
+		if name == old {
+			return
+		}
+		p := fset.Position(pos)
+		if edits[p.Filename] == nil {
+			edits[p.Filename] = make(map[int]TextEdit)
+		}
+		edits[p.Filename][p.Offset] = TextEdit{Start: p.Offset, End: p.Offset + len(old), New: name}
+	}
+
+	// renamed collects the declarations renamed in each moved package, for
+	// the comments mentioning them
+	renamed := make(map[string]bool)
+
+	renameIdent := func(pkg *packages.Package, id *ast.Ident, obj types.Object) {
+		switch obj := obj.(type) {
+		case nil:
+			return
+		case *types.PkgName:
+			if _, moved := moves[obj.Imported().Path()]; !moved {
+				return
+			}
+		default:
+			if obj.Pkg() == nil || !owned(obj.Pkg().Path()) {
+				return
+			}
+			if _, moved := moves[strings.TrimSuffix(obj.Pkg().Path(), "_test")]; moved {
+				renamed[obj.Name()] = true
+			}
+		}
+		add(pkg.Fset, id.Pos(), id.Name, rename(id.Name))
+	}
+
+	for _, pkg := range pkgs {
+		// Skip the generated test main packages
+		if strings.HasSuffix(pkg.PkgPath, ".test") || pkg.TypesInfo == nil {
+			continue
+		}
+
+		for _, file := range pkg.Syntax {
+			for _, spec := range file.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if err != nil {
+					continue
+				}
+				if to, moved := moves[path]; moved {
+					add(pkg.Fset, spec.Path.Pos(), spec.Path.Value, strconv.Quote(to))
+				}
+			}
+			if _, moved := moves[strings.TrimSuffix(pkg.PkgPath, "_test")]; moved {
+				add(pkg.Fset, file.Name.Pos(), file.Name.Name, rename(file.Name.Name))
+			}
+		}
+
+		for id, obj := range pkg.TypesInfo.Defs {
+			renameIdent(pkg, id, obj)
+		}
+		for id, obj := range pkg.TypesInfo.Uses {
+			renameIdent(pkg, id, obj)
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if _, moved := moves[strings.TrimSuffix(pkg.PkgPath, "_test")]; !moved || strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, group := range file.Comments {
+				for _, c := range group.List {
+					renameComment(c, renamed, rename, func(offset int, old, name string) {
+						add(pkg.Fset, c.Pos()+token.Pos(offset), old, name)
+					})
+				}
+			}
+		}
+	}
+
+	result := make(map[string][]TextEdit, len(edits))
+	for file, byOffset := range edits {
+		for _, edit := range byOffset {
+			result[file] = append(result[file], edit)
+		}
+		slices.SortFunc(result[file], func(a, b TextEdit) int { return a.Start - b.Start })
+	}
+	return result
+}
+
+// renameComment calls add for each word of a comment naming a renamed
+// declaration
+func renameComment(c *ast.Comment, renamed map[string]bool, rename func(string) string, add func(offset int, old, name string)) {
+	isWord := func(r rune) bool { return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+	start := -1
+	for i, r := range c.Text + " " {
+		switch {
+		case isWord(r) && start < 0:
+			start = i
+		case !isWord(r) && start >= 0:
+			if word := c.Text[start:i]; renamed[word] {
+				add(start, word, rename(word))
+			}
+			start = -1
+		}
+	}
+}
+
+// ApplyEdits returns content with edits, sorted by offset, applied. An edit
+// overlapping the one before it is dropped.
+func ApplyEdits(content []byte, edits []TextEdit) []byte {
+	var b strings.Builder
+	last := 0
+	for _, edit := range edits {
+		if edit.Start < last {
+			continue
+		}
+		b.Write(content[last:edit.Start])
+		b.WriteString(edit.New)
+		last = edit.End
+	}
+	b.Write(content[last:])
+	return []byte(b.String())
+}
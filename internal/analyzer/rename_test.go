@@ -0,0 +1,207 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenameEdits(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.21\n",
+		"internal/core/domain/orders/order.go": `package orders
+
+// Order is a placed order
+type Order struct {
+	ID string
+}
+
+// NewOrder returns an Order
+func NewOrder(id string) *Order {
+	return &Order{ID: id}
+}
+`,
+		"internal/shop/shop.go": `package shop
+
+import (
+	"fmt"
+
+	"example.com/demo/internal/core/domain/orders"
+)
+
+const table = "orders"
+
+// CountOrders counts the orders
+func CountOrders(list []*orders.Order) string {
+	return fmt.Sprint(len(list), table)
+}
+`,
+		"internal/report/report.go": `package report
+
+import ord "example.com/demo/internal/core/domain/orders"
+
+// First returns the first Order
+func First() *ord.Order {
+	return ord.NewOrder("orders-1")
+}
+`,
+	} {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkgs, err := LoadSyntax(dir)
+	if err != nil {
+		t.Fatalf("LoadSyntax() error = %v", err)
+	}
+	moves := map[string]string{
+		"example.com/demo/internal/core/domain/orders": "example.com/demo/internal/core/domain/purchases",
+	}
+	scope := []string{"example.com/demo/internal/shop"}
+	rename := strings.NewReplacer("Order", "Purchase", "orders", "purchases").Replace
+	edits := RenameEdits(pkgs, moves, scope, rename)
+
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{
+			name: "moved package",
+			file: "internal/core/domain/orders/order.go",
+			want: `package purchases
+
+// Purchase is a placed order
+type Purchase struct {
+	ID string
+}
+
+// NewPurchase returns an Purchase
+func NewPurchase(id string) *Purchase {
+	return &Purchase{ID: id}
+}
+`,
+		},
+		{
+			name: "import move in a scope package",
+			file: "internal/shop/shop.go",
+			want: `package shop
+
+import (
+	"fmt"
+
+	"example.com/demo/internal/core/domain/purchases"
+)
+
+const table = "orders"
+
+// CountOrders counts the orders
+func CountPurchases(list []*purchases.Purchase) string {
+	return fmt.Sprint(len(list), table)
+}
+`,
+		},
+		{
+			name: "aliased import",
+			file: "internal/report/report.go",
+			want: `package report
+
+import ord "example.com/demo/internal/core/domain/purchases"
+
+// First returns the first Order
+func First() *ord.Purchase {
+	return ord.NewPurchase("orders-1")
+}
+`,
+		},
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(root, tt.file)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(ApplyEdits(content, edits[path])); got != tt.want {
+				t.Errorf("ApplyEdits(%s) =\n%s\nwant:\n%s", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		edits   []TextEdit
+		want    string
+	}{
+		{
+			name:    "no edits",
+			content: "type Order struct{}",
+			want:    "type Order struct{}",
+		},
+		{
+			name:    "longer and shorter replacements",
+			content: "var o orders.Order",
+			edits: []TextEdit{
+				{Start: 6, End: 12, New: "purchases"},
+				{Start: 13, End: 18, New: "Buy"},
+			},
+			want: "var o purchases.Buy",
+		},
+		{
+			name:    "edits at both ends",
+			content: "Order(x).Order",
+			edits: []TextEdit{
+				{Start: 0, End: 5, New: "Purchase"},
+				{Start: 9, End: 14, New: "Purchase"},
+			},
+			want: "Purchase(x).Purchase",
+		},
+		{
+			name:    "insertion",
+			content: `import "orders"`,
+			edits:   []TextEdit{{Start: 7, End: 7, New: "ord "}},
+			want:    `import ord "orders"`,
+		},
+		{
+			name:    "overlapping edits keep the first",
+			content: "NewOrder(id)",
+			edits: []TextEdit{
+				{Start: 0, End: 8, New: "NewPurchase"},
+				{Start: 3, End: 8, New: "Purchase"},
+			},
+			want: "NewPurchase(id)",
+		},
+		{
+			name:    "edits at the same offset keep the first",
+			content: "Order",
+			edits: []TextEdit{
+				{Start: 0, End: 5, New: "Purchase"},
+				{Start: 0, End: 5, New: "Sale"},
+			},
+			want: "Purchase",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(ApplyEdits([]byte(tt.content), tt.edits)); got != tt.want {
+				t.Errorf("ApplyEdits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,13 +3,14 @@ package generator
 import (
 	"fmt"
 	"go/ast"
+	"go/format"
+	"go/parser"
 	"go/token"
 	"path/filepath"
 	"strings"
 
 	"github.com/padiazg/hexago/internal/analyzer"
 	"github.com/padiazg/hexago/pkg/utils"
-	"golang.org/x/tools/go/packages"
 )
 
 // AdapterGenerator generates adapter files
@@ -28,6 +29,13 @@ func NewAdapterGenerator(config *ProjectConfig) *AdapterGenerator {
 // For HTTP adapters, entityName (optional) triggers sub-package generation with
 // two files: <snake_entity>.go (Config/DTOs) and handlers.go (HTTP methods).
 func (g *AdapterGenerator) GeneratePrimary(adapterType, adapterName, entityName, portName string) error {
+	return g.config.transact(func() error {
+		return g.generatePrimary(adapterType, adapterName, entityName, portName)
+	})
+}
+
+// generatePrimary does the work of GeneratePrimary inside its transaction
+func (g *AdapterGenerator) generatePrimary(adapterType, adapterName, entityName, portName string) error {
 	// Validate adapter type
 	validTypes := map[string]bool{
 		"http":  true,
@@ -42,12 +50,12 @@ func (g *AdapterGenerator) GeneratePrimary(adapterType, adapterName, entityName,
 
 	// HTTP + entity → sub-package with two files
 	if adapterType == "http" && entityName != "" {
-		return g.generateHTTPHandlerPackage(adapterName, entityName)
+		return g.generateHTTPHandlerPackage(adapterName, entityName, nil)
 	}
 
 	// Default: flat directory
 	adapterDir := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), adapterType)
-	if err := utils.CreateDir(adapterDir); err != nil {
+	if err := g.config.createDir(adapterDir); err != nil {
 		return err
 	}
 
@@ -56,7 +64,7 @@ func (g *AdapterGenerator) GeneratePrimary(adapterType, adapterName, entityName,
 	filePath := filepath.Join(adapterDir, fileName)
 	testFilePath := filepath.Join(adapterDir, testFileName)
 
-	if utils.FileExists(filePath) {
+	if g.config.fileExists(filePath) {
 		return fmt.Errorf("adapter file %s already exists", filePath)
 	}
 
@@ -75,12 +83,20 @@ func (g *AdapterGenerator) GeneratePrimary(adapterType, adapterName, entityName,
 		if err := g.generateQueueAdapter(filePath, adapterName); err != nil {
 			return err
 		}
+	case "cli":
+		if err := g.generateCLIAdapter(filePath, adapterName); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("adapter type %s not yet implemented", adapterType)
 	}
 
 	fmt.Printf("📝 Creating test file: %s\n", testFilePath)
 
+	if adapterType == "cli" {
+		return g.generateCLIAdapterTestFile(testFilePath, adapterName)
+	}
+
 	if err := g.generateAdapterTestFile(testFilePath, adapterName, adapterType, nil); err != nil {
 		return err
 	}
@@ -88,19 +104,36 @@ func (g *AdapterGenerator) GeneratePrimary(adapterType, adapterName, entityName,
 	return nil
 }
 
+// handlerDTOs are the DTO fields of a generated HTTP handler package. Empty
+// lists leave the corresponding DTO as a TODO scaffold.
+type handlerDTOs struct {
+	Create   []dtoField
+	Update   []dtoField
+	Response []dtoField
+}
+
+// dtoField is a DTO struct field
+type dtoField struct {
+	Name   string
+	Type   string
+	Tag    string // json tag value
+	Mapped bool   // the entity has a field with the same name and type
+}
+
 // generateHTTPHandlerPackage generates the two-file per-entity HTTP handler sub-package.
-func (g *AdapterGenerator) generateHTTPHandlerPackage(adapterName, entityName string) error {
+// dtos (optional) provides the request and response DTO fields.
+func (g *AdapterGenerator) generateHTTPHandlerPackage(adapterName, entityName string, dtos *handlerDTOs) error {
 	pkgName := utils.ToPlural(strings.ToLower(entityName))
 	adapterDir := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "http", pkgName)
 
-	if err := utils.CreateDir(adapterDir); err != nil {
+	if err := g.config.createDir(adapterDir); err != nil {
 		return err
 	}
 
 	configFile := filepath.Join(adapterDir, utils.ToSnakeCase(entityName)+".go")
 	handlersFile := filepath.Join(adapterDir, "handlers.go")
 
-	if utils.FileExists(configFile) {
+	if g.config.fileExists(configFile) {
 		return fmt.Errorf("handler file %s already exists", configFile)
 	}
 
@@ -124,6 +157,14 @@ func (g *AdapterGenerator) generateHTTPHandlerPackage(adapterName, entityName st
 		"ServiceName":        entityName,
 		"ServiceField":       serviceField,
 		"RoutePrefix":        routePrefix,
+		"ImportTime":         true,
+	}
+
+	if dtos != nil {
+		data["CreateFields"] = dtos.Create
+		data["UpdateFields"] = dtos.Update
+		data["ResponseFields"] = dtos.Response
+		data["ImportTime"] = len(dtos.Response) == 0 || usesTime(dtos.Create, dtos.Update, dtos.Response)
 	}
 
 	framework := g.config.Framework
@@ -131,13 +172,27 @@ func (g *AdapterGenerator) generateHTTPHandlerPackage(adapterName, entityName st
 		framework = "chi"
 	}
 
+	// The chi handlers report errors through pkg/httphelpers; the others
+	// map domain.ErrNotFound themselves
+	if framework != "chi" {
+		if err := g.EnsureDomainError("ErrNotFound", "entity not found"); err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("📝 Creating handler config file: %s\n", configFile)
 	configTmpl := fmt.Sprintf("adapter/primary/http/%s/handler_config.go.tmpl", framework)
 	configContent, err := g.config.templateLoader.Render(configTmpl, data)
 	if err != nil {
 		return fmt.Errorf("failed to render handler config template: %w", err)
 	}
-	if err := utils.WriteFile(configFile, configContent); err != nil {
+	if dtos != nil {
+		// Spec-derived fields are not aligned by the template
+		if formatted, err := format.Source(configContent); err == nil {
+			configContent = formatted
+		}
+	}
+	if err := g.config.writeGenerated(configFile, configTmpl, configContent); err != nil {
 		return err
 	}
 
@@ -147,13 +202,36 @@ func (g *AdapterGenerator) generateHTTPHandlerPackage(adapterName, entityName st
 	if err != nil {
 		return fmt.Errorf("failed to render handler methods template: %w", err)
 	}
-	return utils.WriteFile(handlersFile, methodsContent)
+	if err := g.config.writeGenerated(handlersFile, methodsTmpl, methodsContent); err != nil {
+		return err
+	}
+
+	return g.upsertRoutes()
+}
+
+// usesTime reports whether any DTO field needs the time package
+func usesTime(lists ...[]dtoField) bool {
+	for _, fields := range lists {
+		for _, f := range fields {
+			if strings.Contains(f.Type, "time.") {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // GenerateSecondary generates a secondary (outbound) adapter.
 // For database adapters, entityName (optional) drives the sub-package and entity wiring.
 // portInfo (optional) provides method signatures for code generation.
 func (g *AdapterGenerator) GenerateSecondary(adapterType, adapterName, entityName, portName string, portInfo *analyzer.PortInfo) error {
+	return g.config.transact(func() error {
+		return g.generateSecondary(adapterType, adapterName, entityName, portName, portInfo)
+	})
+}
+
+// generateSecondary does the work of GenerateSecondary inside its transaction
+func (g *AdapterGenerator) generateSecondary(adapterType, adapterName, entityName, portName string, portInfo *analyzer.PortInfo) error {
 	// Validate adapter type
 	validTypes := map[string]bool{
 		"database": true,
@@ -176,21 +254,21 @@ func (g *AdapterGenerator) GenerateSecondary(adapterType, adapterName, entityNam
 			pkgName = strings.ToLower(adapterName)
 		}
 		adapterDir = filepath.Join("internal", "adapters", g.config.AdapterOutboundDir(), "database", pkgName)
-		if err := utils.CreateDir(adapterDir); err != nil {
+		if err := g.config.createDir(adapterDir); err != nil {
 			return err
 		}
-		filePath = filepath.Join(g.config.OutputDir, adapterDir, pkgName+".go")
-		testFilePath = filepath.Join(g.config.OutputDir, adapterDir, pkgName+"_test.go")
+		filePath = filepath.Join(adapterDir, pkgName+".go")
+		testFilePath = filepath.Join(adapterDir, pkgName+"_test.go")
 	} else {
 		adapterDir = filepath.Join("internal", "adapters", g.config.AdapterOutboundDir(), adapterType)
-		if err := utils.CreateDir(adapterDir); err != nil {
+		if err := g.config.createDir(adapterDir); err != nil {
 			return err
 		}
-		filePath = filepath.Join(g.config.OutputDir, adapterDir, utils.ToSnakeCase(adapterName)+".go")
-		testFilePath = filepath.Join(g.config.OutputDir, adapterDir, utils.ToSnakeCase(adapterName)+"_test.go")
+		filePath = filepath.Join(adapterDir, utils.ToSnakeCase(adapterName)+".go")
+		testFilePath = filepath.Join(adapterDir, utils.ToSnakeCase(adapterName)+"_test.go")
 	}
 
-	if utils.FileExists(filePath) {
+	if g.config.fileExists(filePath) {
 		return fmt.Errorf("adapter file %s already exists", filePath)
 	}
 
@@ -233,6 +311,13 @@ func (g *AdapterGenerator) GenerateSecondary(adapterType, adapterName, entityNam
 		return err
 	}
 
+	if adapterType == "database" {
+		if err := g.config.rewire(); err != nil {
+			return err
+		}
+		return g.config.reportRepositoryWiring(adapterDir)
+	}
+
 	return nil
 }
 
@@ -249,7 +334,7 @@ func (g *AdapterGenerator) generateHTTPAdapter(filePath, handlerName string) err
 		return fmt.Errorf("failed to render HTTP adapter template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/http.go.tmpl", content)
 }
 
 // generateGRPCAdapter generates a gRPC handler adapter
@@ -265,7 +350,7 @@ func (g *AdapterGenerator) generateGRPCAdapter(filePath, handlerName string) err
 		return fmt.Errorf("failed to render gRPC adapter template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/grpc.go.tmpl", content)
 }
 
 // generateQueueAdapter generates a message queue consumer adapter
@@ -281,7 +366,42 @@ func (g *AdapterGenerator) generateQueueAdapter(filePath, consumerName string) e
 		return fmt.Errorf("failed to render queue adapter template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/queue.go.tmpl", content)
+}
+
+// generateCLIAdapter generates a Cobra subcommand adapter.
+// The command still has to be registered in Commands (cli.go).
+func (g *AdapterGenerator) generateCLIAdapter(filePath, commandName string) error {
+	if !g.config.fileExists(filepath.Join(filepath.Dir(filePath), "cli.go")) {
+		fmt.Println("⚠️  Warning: cli.go not found; the command needs a cli.Config type and registration on the root command")
+	}
+
+	content, err := g.config.templateLoader.Render("adapter/cli.go.tmpl", g.cliAdapterData(commandName))
+	if err != nil {
+		return fmt.Errorf("failed to render CLI adapter template: %w", err)
+	}
+
+	return g.config.writeGenerated(filePath, "adapter/cli.go.tmpl", content)
+}
+
+// generateCLIAdapterTestFile generates a test that executes the command
+func (g *AdapterGenerator) generateCLIAdapterTestFile(filePath, commandName string) error {
+	content, err := g.config.templateLoader.Render("adapter/cli_test.go.tmpl", g.cliAdapterData(commandName))
+	if err != nil {
+		return fmt.Errorf("failed to render CLI adapter test template: %w", err)
+	}
+
+	return g.config.writeGenerated(filePath, "adapter/cli_test.go.tmpl", content)
+}
+
+// cliAdapterData returns the template data for a CLI command adapter
+func (g *AdapterGenerator) cliAdapterData(commandName string) map[string]any {
+	return map[string]any{
+		"ModuleName":        g.config.ModuleName,
+		"AdapterInboundDir": g.config.AdapterInboundDir(),
+		"CommandName":       commandName,
+		"CommandUse":        utils.ToKebabCase(commandName),
+	}
 }
 
 // generateDatabaseAdapter generates a database repository adapter
@@ -302,6 +422,11 @@ func (g *AdapterGenerator) generateDatabaseAdapter(filePath, repoName, entityNam
 	}
 	entityImportAlias = pkgName + "Domain"
 
+	errNoRows := "sql.ErrNoRows"
+	if g.config.DBDriver == "pgx" {
+		errNoRows = "pgx.ErrNoRows"
+	}
+
 	data := map[string]any{
 		"ModuleName":        g.config.ModuleName,
 		"PackageName":       pkgName,
@@ -309,6 +434,11 @@ func (g *AdapterGenerator) generateDatabaseAdapter(filePath, repoName, entityNam
 		"EntityName":        resolvedEntity,
 		"EntityPackage":     pkgName,
 		"EntityImportAlias": entityImportAlias,
+		"Dialect":           g.config.dialect().Label,
+		"Driver":            g.config.DBDriver,
+		"DBType":            sqlDBType(g.config.DBDriver),
+		"IDParam":           g.config.dialect().Placeholder(1),
+		"ErrNoRows":         errNoRows,
 	}
 
 	content, err := g.config.templateLoader.Render("adapter/database.go.tmpl", data)
@@ -316,7 +446,7 @@ func (g *AdapterGenerator) generateDatabaseAdapter(filePath, repoName, entityNam
 		return fmt.Errorf("failed to render database adapter template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/database.go.tmpl", content)
 }
 
 // FIXME: adapter don't get it's own folder and package
@@ -341,7 +471,7 @@ func (g *AdapterGenerator) generateExternalAdapter(filePath, serviceName, portNa
 		return fmt.Errorf("failed to render external adapter template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/external.go.tmpl", content)
 }
 
 // generateCacheAdapter generates a cache adapter
@@ -361,7 +491,7 @@ func (g *AdapterGenerator) generateCacheAdapter(filePath, cacheName, portName st
 		return fmt.Errorf("failed to render cache adapter template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/cache.go.tmpl", content)
 }
 
 // generatePortInterface generates a port interface (if using explicit ports)
@@ -388,16 +518,16 @@ func (g *AdapterGenerator) generateAdapterTestFile(filePath, adapterName, adapte
 		return fmt.Errorf("failed to render adapter test template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "adapter/adapter_test.go.tmpl", content)
 }
 
 // EnsureDomainError ensures an error exists in domain/errors.go.
 // If the file doesn't exist, create it with the error.
-// If it exists, use go/packages to check if error is already defined.
+// If it exists, parse it to check if error is already defined.
 func (g *AdapterGenerator) EnsureDomainError(errorName, errorMessage string) error {
-	errorsFile := filepath.Join(g.config.OutputDir, "internal", "core", "domain", "errors.go")
+	errorsFile := filepath.Join("internal", "core", "domain", "errors.go")
 
-	if !utils.FileExists(errorsFile) {
+	if !g.config.fileExists(errorsFile) {
 		return g.createErrorsFile(errorsFile, errorName, errorMessage)
 	}
 
@@ -421,41 +551,36 @@ func (g *AdapterGenerator) createErrorsFile(filePath, errorName, errorMessage st
 		return fmt.Errorf("failed to render errors template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "domain/errors.go.tmpl", content)
 }
 
 // isErrorDefined checks if an error with the given name is already defined in the file.
 func (g *AdapterGenerator) isErrorDefined(filePath, errorName string) bool {
-	cfg := &packages.Config{
-		Mode: packages.NeedSyntax | packages.NeedTypes,
-	}
-
-	pkgs, err := packages.Load(cfg, "file="+filePath)
+	content, err := g.config.readFile(filePath)
 	if err != nil {
 		return false
 	}
 
-	if len(pkgs) == 0 {
+	syn, err := parser.ParseFile(token.NewFileSet(), filePath, content, parser.SkipObjectResolution)
+	if err != nil {
 		return false
 	}
 
-	for _, syn := range pkgs[0].Syntax {
-		for _, decl := range syn.Decls {
-			gd, ok := decl.(*ast.GenDecl)
-			if !ok || gd.Tok != token.VAR {
+	for _, decl := range syn.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
 				continue
 			}
 
-			for _, spec := range gd.Specs {
-				vs, ok := spec.(*ast.ValueSpec)
-				if !ok {
-					continue
-				}
-
-				for _, ident := range vs.Names {
-					if ident.Name == errorName {
-						return true
-					}
+			for _, ident := range vs.Names {
+				if ident.Name == errorName {
+					return true
 				}
 			}
 		}
@@ -466,7 +591,7 @@ func (g *AdapterGenerator) isErrorDefined(filePath, errorName string) bool {
 
 // appendErrorToFile appends a new error to an existing errors.go file.
 func (g *AdapterGenerator) appendErrorToFile(filePath, errorName, errorMessage string) error {
-	content, err := utils.ReadFile(filePath)
+	content, err := g.config.readFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read errors file: %w", err)
 	}
@@ -474,7 +599,7 @@ func (g *AdapterGenerator) appendErrorToFile(filePath, errorName, errorMessage s
 	newError := fmt.Sprintf("\n// %s is returned when %s.\nvar %s = errors.New(\"%s\")",
 		errorName, strings.ToLower(errorMessage), errorName, errorMessage)
 
-	newContent := strings.TrimSpace(content) + newError + "\n"
+	newContent := strings.TrimSpace(string(content)) + newError + "\n"
 
-	return utils.WriteFile(filePath, []byte(newContent))
+	return g.config.writeFile(filePath, []byte(newContent))
 }
@@ -44,7 +44,12 @@ func (d *ProjectDetector) DetectConfig() (*ProjectConfig, error) {
 		return nil, fmt.Errorf("not a hexagonal architecture project (internal/core not found)")
 	}
 
-	config := &ProjectConfig{}
+	config := &ProjectConfig{
+		DBDialect:           "postgres",
+		DBDriver:            "database/sql",
+		MigrationVersioning: "sequential",
+		templateLoader:      NewTemplateLoader(),
+	}
 
 	// Detect module name from go.mod
 	moduleName, err := d.detectModuleName()
@@ -169,5 +174,11 @@ func GetCurrentProjectConfig(dir string) (*ProjectConfig, error) {
 	}
 
 	detector := NewProjectDetector(dir)
-	return detector.DetectConfig()
+	config, err := detector.DetectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config.templateLoader.SetProjectDir(dir)
+	return config, nil
 }
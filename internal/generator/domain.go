@@ -28,15 +28,22 @@ func NewDomainGenerator(config *ProjectConfig) *DomainGenerator {
 
 // GenerateEntity creates a new domain entity
 func (g *DomainGenerator) GenerateEntity(entityName string, fields []Field) error {
+	return g.config.transact(func() error {
+		return g.generateEntity(entityName, fields)
+	})
+}
+
+// generateEntity does the work of GenerateEntity inside its transaction
+func (g *DomainGenerator) generateEntity(entityName string, fields []Field) error {
 	baseDomainDir := filepath.Join("internal", "core", "domain")
-	if !utils.FileExists(baseDomainDir) {
+	if !g.config.fileExists(baseDomainDir) {
 		return fmt.Errorf("directory %s does not exist", baseDomainDir)
 	}
 
 	pkgName := utils.ToPlural(strings.ToLower(entityName))
 	domainDir := filepath.Join(baseDomainDir, pkgName)
 
-	if err := utils.CreateDir(domainDir); err != nil {
+	if err := g.config.createDir(domainDir); err != nil {
 		return fmt.Errorf("creating directory %s: %w", domainDir, err)
 	}
 
@@ -46,7 +53,7 @@ func (g *DomainGenerator) GenerateEntity(entityName string, fields []Field) erro
 	filePath := filepath.Join(domainDir, fileName)
 	testFilePath := filepath.Join(domainDir, testFileName)
 
-	if utils.FileExists(filePath) {
+	if g.config.fileExists(filePath) {
 		return fmt.Errorf("entity file %s already exists", filePath)
 	}
 
@@ -75,8 +82,15 @@ func (g *DomainGenerator) GenerateEntity(entityName string, fields []Field) erro
 // If entityName is non-empty, the VO is co-located inside that entity's sub-package.
 // If entityName is empty, the VO gets its own standalone sub-package.
 func (g *DomainGenerator) GenerateValueObject(voName, entityName string, fields []Field) error {
+	return g.config.transact(func() error {
+		return g.generateValueObject(voName, entityName, fields)
+	})
+}
+
+// generateValueObject does the work of GenerateValueObject inside its transaction
+func (g *DomainGenerator) generateValueObject(voName, entityName string, fields []Field) error {
 	baseDomainDir := filepath.Join("internal", "core", "domain")
-	if !utils.FileExists(baseDomainDir) {
+	if !g.config.fileExists(baseDomainDir) {
 		return fmt.Errorf("directory %s does not exist", baseDomainDir)
 	}
 
@@ -85,14 +99,14 @@ func (g *DomainGenerator) GenerateValueObject(voName, entityName string, fields
 		// Entity-bound: co-locate inside the entity's sub-package (must already exist)
 		pkgName = utils.ToPlural(strings.ToLower(entityName))
 		voDir = filepath.Join(baseDomainDir, pkgName)
-		if !utils.FileExists(voDir) {
+		if !g.config.fileExists(voDir) {
 			return fmt.Errorf("entity directory %s does not exist; create the entity first", voDir)
 		}
 	} else {
 		// Standalone: own sub-package named after the VO
 		pkgName = strings.ToLower(voName)
 		voDir = filepath.Join(baseDomainDir, pkgName)
-		if err := utils.CreateDir(voDir); err != nil {
+		if err := g.config.createDir(voDir); err != nil {
 			return fmt.Errorf("creating directory %s: %w", voDir, err)
 		}
 	}
@@ -103,7 +117,7 @@ func (g *DomainGenerator) GenerateValueObject(voName, entityName string, fields
 	filePath := filepath.Join(voDir, fileName)
 	testFilePath := filepath.Join(voDir, testFileName)
 
-	if utils.FileExists(filePath) {
+	if g.config.fileExists(filePath) {
 		return fmt.Errorf("value object file %s already exists", filePath)
 	}
 
@@ -132,15 +146,6 @@ func (g *DomainGenerator) generateEntityFile(filePath, entityName, pkgName strin
 		}
 	}
 
-	imports := `import (
-	"errors"
-`
-	if hasTimeField {
-		imports += `	"time"
-`
-	}
-	imports += ")"
-
 	// Generate field definitions
 	fieldDefs := ""
 	if len(fields) > 0 {
@@ -154,10 +159,11 @@ func (g *DomainGenerator) generateEntityFile(filePath, entityName, pkgName strin
 	UpdatedAt time.Time
 `
 		hasTimeField = true
-		imports = `import (
-	"errors"
-	"time"
-)`
+	}
+
+	imports := ""
+	if hasTimeField {
+		imports = `import "time"`
 	}
 
 	data := map[string]any{
@@ -172,7 +178,7 @@ func (g *DomainGenerator) generateEntityFile(filePath, entityName, pkgName strin
 		return fmt.Errorf("failed to render entity template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "domain/entity.go.tmpl", content)
 }
 
 // generatePortFile generates the repository port interface for an entity
@@ -187,7 +193,7 @@ func (g *DomainGenerator) generatePortFile(filePath, entityName, pkgName string)
 		return fmt.Errorf("failed to render port template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "domain/port.go.tmpl", content)
 }
 
 // generateEntityTestFile generates entity test file
@@ -203,7 +209,7 @@ func (g *DomainGenerator) generateEntityTestFile(filePath, entityName, pkgName s
 		return fmt.Errorf("failed to render entity test template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "domain/entity_test.go.tmpl", content)
 }
 
 // generateValueObjectFile generates the value object implementation
@@ -217,7 +223,6 @@ func (g *DomainGenerator) generateValueObjectFile(filePath, voName, pkgName stri
 	}
 
 	imports := `import (
-	"errors"
 	"fmt"
 `
 	if hasTimeField {
@@ -249,7 +254,7 @@ func (g *DomainGenerator) generateValueObjectFile(filePath, voName, pkgName stri
 		return fmt.Errorf("failed to render value object template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "domain/value_object.go.tmpl", content)
 }
 
 // generateValueObjectTestFile generates value object test file
@@ -265,5 +270,5 @@ func (g *DomainGenerator) generateValueObjectTestFile(filePath, voName, pkgName
 		return fmt.Errorf("failed to render value object test template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "domain/value_object_test.go.tmpl", content)
 }
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// PlannedChange describes a file that a dry run would create, modify or remove
+type PlannedChange struct {
+	Path    string
+	Exists  bool   // true when the file is already on disk
+	Old     []byte // current content on disk (empty for new files)
+	New     []byte // content that would be written
+	Changed bool   // false when the new content equals the current one
+	Removed bool   // true when the file would be removed
+}
+
+// PlannedChanges returns the files written or removed during a dry run,
+// sorted by path
+func (c *ProjectConfig) PlannedChanges() []PlannedChange {
+	if c.overlay == nil {
+		return nil
+	}
+
+	paths := c.overlay.Written()
+	lower := c.overlay.Lower()
+
+	changes := make([]PlannedChange, 0, len(paths))
+	for _, p := range paths {
+		content, err := c.overlay.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		change := PlannedChange{Path: p, New: content, Changed: true}
+		if old, err := lower.ReadFile(p); err == nil {
+			change.Exists = true
+			change.Old = old
+			change.Changed = !bytes.Equal(old, change.New)
+		}
+		changes = append(changes, change)
+	}
+
+	for _, p := range c.overlay.Removed() {
+		if old, err := lower.ReadFile(p); err == nil {
+			changes = append(changes, PlannedChange{Path: p, Exists: true, Old: old, Changed: true, Removed: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
+
+// PrintDryRun prints the planned file tree followed by unified diffs for
+// every existing file that would be modified.
+func (c *ProjectConfig) PrintDryRun(w io.Writer) {
+	changes := c.PlannedChanges()
+
+	fmt.Fprintln(w, "\n🔎 Dry run — no files were written")
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "\nNo changes planned.")
+		return
+	}
+
+	fmt.Fprintln(w, "\n📋 Planned file tree (+ new, ~ modified, - removed, = unchanged):")
+	printPlannedTree(w, changes)
+
+	var created, modified, removed int
+	for _, change := range changes {
+		switch {
+		case change.Removed:
+			removed++
+		case !change.Exists:
+			created++
+		case change.Changed:
+			modified++
+			fmt.Fprintln(w)
+			fmt.Fprint(w, utils.UnifiedDiff("a/"+filepath.ToSlash(change.Path), "b/"+filepath.ToSlash(change.Path),
+				string(change.Old), string(change.New)))
+		}
+	}
+
+	if removed > 0 {
+		fmt.Fprintf(w, "\n📊 %d file(s) would be created, %d modified, %d removed\n", created, modified, removed)
+		return
+	}
+	fmt.Fprintf(w, "\n📊 %d file(s) would be created, %d modified\n", created, modified)
+}
+
+// printPlannedTree renders changes as an indented directory tree
+func printPlannedTree(w io.Writer, changes []PlannedChange) {
+	var previous []string
+	for _, change := range changes {
+		parts := strings.Split(filepath.ToSlash(change.Path), "/")
+		dirs := parts[:len(parts)-1]
+
+		// Print directory components that differ from the previous path
+		common := 0
+		for common < len(dirs) && common < len(previous) && dirs[common] == previous[common] {
+			common++
+		}
+		for i := common; i < len(dirs); i++ {
+			fmt.Fprintf(w, "  %s%s/\n", strings.Repeat("  ", i), dirs[i])
+		}
+		previous = dirs
+
+		marker := "+"
+		switch {
+		case change.Removed:
+			marker = "-"
+		case change.Exists && change.Changed:
+			marker = "~"
+		case change.Exists:
+			marker = "="
+		}
+		fmt.Fprintf(w, "  %s%s %s\n", strings.Repeat("  ", len(dirs)), marker, parts[len(parts)-1])
+	}
+}
+
+// printChanges prints the files a dry run would create, modify, move or
+// remove, and the directories it would create, followed by the unified
+// diff of every change. moves maps the directories the dry run moves to
+// their new location: their files are diffed against their old content.
+func (c *ProjectConfig) printChanges(w io.Writer, moves map[string]string) {
+	if c.overlay == nil {
+		return
+	}
+	changes := c.PlannedChanges()
+	fmt.Fprintln(w, "\n📋 Changes (+ new, ~ modified, > moved, - removed):")
+
+	// The removed files a written one was moved from
+	movedFrom := make(map[string]PlannedChange)
+	for _, change := range changes {
+		if !change.Removed {
+			continue
+		}
+		for oldDir, newDir := range moves {
+			if rest, ok := strings.CutPrefix(change.Path, oldDir+string(filepath.Separator)); ok {
+				movedFrom[filepath.Join(newDir, rest)] = change
+			}
+		}
+	}
+	moved := make(map[string]bool, len(movedFrom))
+	for _, from := range movedFrom {
+		moved[from.Path] = true
+	}
+
+	var diffs []string
+	for _, change := range changes {
+		path := filepath.ToSlash(change.Path)
+		from, isMove := movedFrom[change.Path]
+		switch {
+		case change.Removed:
+			if !moved[change.Path] {
+				fmt.Fprintf(w, "  - %s\n", path)
+			}
+		case isMove:
+			fromPath := filepath.ToSlash(from.Path)
+			fmt.Fprintf(w, "  > %s → %s\n", fromPath, path)
+			diffs = append(diffs, utils.UnifiedDiff("a/"+fromPath, "b/"+path, string(from.Old), string(change.New)))
+		case !change.Exists:
+			fmt.Fprintf(w, "  + %s\n", path)
+			diffs = append(diffs, utils.UnifiedDiff("/dev/null", "b/"+path, "", string(change.New)))
+		case change.Changed:
+			fmt.Fprintf(w, "  ~ %s\n", path)
+			diffs = append(diffs, utils.UnifiedDiff("a/"+path, "b/"+path, string(change.Old), string(change.New)))
+		}
+	}
+
+	// Directories created empty
+	dirs := c.overlay.Dirs()
+	for _, dir := range dirs {
+		if fsys.Exists(c.overlay.Lower(), dir) {
+			continue
+		}
+		prefix := dir + string(filepath.Separator)
+		filled := slices.ContainsFunc(c.overlay.Written(), func(p string) bool { return strings.HasPrefix(p, prefix) }) ||
+			slices.ContainsFunc(dirs, func(d string) bool { return strings.HasPrefix(d, prefix) })
+		if !filled {
+			fmt.Fprintf(w, "  + %s/\n", filepath.ToSlash(dir))
+		}
+	}
+
+	for _, diff := range diffs {
+		if diff != "" {
+			fmt.Fprintln(w)
+			fmt.Fprint(w, diff)
+		}
+	}
+}
+
+// progressf prints a progress line, e.g. "📝 Updating <file>", unless
+// progress lines are left out
+func (c *ProjectConfig) progressf(format string, args ...any) {
+	if !c.quiet {
+		fmt.Printf(format, args...)
+	}
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+// filesystem returns the filesystem generators read from and write to.
+// Relative paths resolve against OutputDir; in dry-run mode every write is
+// captured by an in-memory overlay instead of reaching the disk.
+func (c *ProjectConfig) filesystem() fsys.FS {
+	if c.FS == nil {
+		c.FS = fsys.NewOS(c.OutputDir)
+	}
+
+	if c.DryRun {
+		if c.overlay == nil {
+			c.overlay = fsys.NewOverlay(c.FS)
+		}
+		return c.overlay
+	}
+
+	if c.tx != nil {
+		return c.tx
+	}
+
+	return c.FS
+}
+
+// transact runs fn with every write staged in memory and commits the staged
+// changes only if fn succeeds, so a failed generation leaves no debris behind.
+func (c *ProjectConfig) transact(fn func() error) error {
+	_, err := c.stage(fn)
+	return err
+}
+
+// stage is like transact but also returns the committed transaction, so
+// steps that must run against the real files (e.g. go mod tidy) can roll
+// it back when they fail. It returns a nil transaction in dry-run mode and
+// when called from within another transaction, which owns the commit.
+func (c *ProjectConfig) stage(fn func() error) (*fsys.Tx, error) {
+	if c.staging {
+		return nil, fn()
+	}
+	c.staging = true
+	defer func() { c.staging = false }()
+
+	if c.DryRun {
+		if err := fn(); err != nil {
+			return nil, err
+		}
+		return nil, c.saveManifest()
+	}
+
+	tx := fsys.Begin(c.filesystem())
+	c.tx = tx
+	err := fn()
+	if err == nil {
+		err = c.saveManifest()
+	}
+	c.tx = nil
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit generated files: %w", err)
+	}
+
+	return tx, nil
+}
+
+// writeFile writes content to path, creating parent directories if needed
+func (c *ProjectConfig) writeFile(path string, content []byte) error {
+	return fsys.WriteFile(c.filesystem(), path, content)
+}
+
+// writeGenerated writes content rendered from the named template to path and
+// records the file in the manifest of .hexago.yaml
+func (c *ProjectConfig) writeGenerated(path, template string, content []byte) error {
+	if err := c.writeFile(path, content); err != nil {
+		return err
+	}
+	c.recordFile(path, template, content)
+	return nil
+}
+
+// createDir creates a directory with all parent directories
+func (c *ProjectConfig) createDir(path string) error {
+	return c.filesystem().MkdirAll(path, 0755)
+}
+
+// createDirs creates multiple directories under basePath
+func (c *ProjectConfig) createDirs(basePath string, dirs []string) error {
+	for _, dir := range dirs {
+		if err := c.createDir(filepath.Join(basePath, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileExists checks if a file or directory exists
+func (c *ProjectConfig) fileExists(path string) bool {
+	return fsys.Exists(c.filesystem(), path)
+}
+
+// isDir checks if path is a directory
+func (c *ProjectConfig) isDir(path string) bool {
+	return fsys.IsDir(c.filesystem(), path)
+}
+
+// readFile reads the content of path
+func (c *ProjectConfig) readFile(path string) ([]byte, error) {
+	return c.filesystem().ReadFile(path)
+}
+
+// removeFile removes a file or empty directory
+func (c *ProjectConfig) removeFile(path string) error {
+	return c.filesystem().Remove(path)
+}
+
+// readDirNames lists the entry names of a directory
+func (c *ProjectConfig) readDirNames(path string) ([]string, error) {
+	return fsys.ReadDirNames(c.filesystem(), path)
+}
@@ -0,0 +1,699 @@
+package generator
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// Fix is an automated fix of a validation violation
+type Fix struct {
+	Rule        string // ID of the rule the fix satisfies
+	Description string
+
+	apply func() error
+	moves map[string]string // directories the fix moves, for the preview
+}
+
+// Fixer plans and applies fixes of validation violations
+type Fixer struct {
+	config *ProjectConfig
+}
+
+// NewFixer creates a new fixer
+func NewFixer(config *ProjectConfig) *Fixer {
+	return &Fixer{
+		config: config,
+	}
+}
+
+// Plan returns the fixes of the violations found in the project, and notes
+// on the ones that can't be fixed automatically. pkgs hold the type
+// information of the project, as loaded by analyzer.LoadSyntax. Fixes are
+// planned against the current files: plan again after applying one.
+func (f *Fixer) Plan(pkgs []*packages.Package) ([]Fix, []string) {
+	var fixes []Fix
+	var notes []string
+
+	// Misnamed adapter directories, renamed before creating missing ones
+	misnamed := f.config.misnamedAdapterDirs()
+	renamed := make(map[string]bool)
+	for _, oldDir := range slices.Sorted(maps.Keys(misnamed)) {
+		newDir := misnamed[oldDir]
+		if f.config.fileExists(newDir) {
+			notes = append(notes, fmt.Sprintf("%s and %s both exist: merge them by hand", oldDir, newDir))
+			continue
+		}
+		renamed[newDir] = true
+		fixes = append(fixes, Fix{
+			Rule:        "naming-conventions",
+			Description: fmt.Sprintf("Rename %s to %s", oldDir, newDir),
+			apply:       func() error { return f.moveAdapterDir(oldDir, newDir, pkgs) },
+			moves:       map[string]string{oldDir: newDir},
+		})
+	}
+
+	// Missing required directories
+	for _, dir := range f.config.requiredDirs() {
+		if f.config.isDir(dir.path) || renamed[dir.path] {
+			continue
+		}
+		fixes = append(fixes, Fix{
+			Rule:        "project-structure",
+			Description: fmt.Sprintf("Create %s", dir.path),
+			apply: func() error {
+				f.config.progressf("📁 Creating %s\n", dir.path)
+				return f.config.createDir(dir.path)
+			},
+		})
+	}
+
+	// Core packages importing adapters
+	portFixes, portNotes := f.planPortExtractions(pkgs)
+	fixes = append(fixes, portFixes...)
+	notes = append(notes, portNotes...)
+
+	return fixes, notes
+}
+
+// Preview prints the changes fix would make, with their diffs, without
+// writing them. The progress lines are left to Apply.
+func (f *Fixer) Preview(w io.Writer, fix Fix) error {
+	dryRun, overlay, quiet := f.config.DryRun, f.config.overlay, f.config.quiet
+	defer func() { f.config.DryRun, f.config.overlay, f.config.quiet = dryRun, overlay, quiet }()
+
+	f.config.DryRun, f.config.overlay, f.config.quiet = true, nil, true
+	if err := f.config.transact(fix.apply); err != nil {
+		return err
+	}
+	f.config.printChanges(w, fix.moves)
+	return nil
+}
+
+// Apply applies fix
+func (f *Fixer) Apply(fix Fix) error {
+	return f.config.transact(fix.apply)
+}
+
+// moveAdapterDir moves the adapter packages of oldDir to newDir, updating
+// the imports of them in the whole project
+func (f *Fixer) moveAdapterDir(oldDir, newDir string, pkgs []*packages.Package) error {
+	c := f.config
+	if c.fileExists(newDir) {
+		return fmt.Errorf("%s already exists", newDir)
+	}
+
+	oldImport, newImport := c.importPath(oldDir), c.importPath(newDir)
+	moves := make(map[string]string)
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, "_test") || strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(pkg.PkgPath, oldImport); ok && (rest == "" || rest[0] == '/') {
+			moves[pkg.PkgPath] = newImport + rest
+		}
+	}
+
+	unchanged := func(name string) string { return name }
+	edits, err := c.relativeEdits(analyzer.RenameEdits(pkgs, moves, nil, unchanged))
+	if err != nil {
+		return err
+	}
+
+	dirs := map[string]string{oldDir: newDir}
+	recorded := c.recordedFiles()
+	err = c.editFiles(edits, recorded, func(path string) bool { return inDirs(path, dirs) })
+	if err != nil {
+		return err
+	}
+	return c.moveDir(oldDir, newDir, nil, edits, recorded)
+}
+
+// portExtraction replaces the uses of the types of an adapter package in a
+// core package with port interfaces of the core
+type portExtraction struct {
+	coreDir    string
+	adapter    string                         // import path of the adapter package
+	domainDir  string                         // directory of the domain package declaring the new ports
+	domainName string                         // package name of the domain package
+	portFile   string                         // file declaring the new ports
+	ports      []string                       // the ports, new or reused, e.g. internal/core/domain/orders.Repository
+	decls      []string                       // declarations of the new ports
+	imports    map[string]string              // imports of the new ports, by path
+	edits      map[string][]analyzer.TextEdit // edits of the core files
+}
+
+// portRef is the port an adapter type is replaced with
+type portRef struct {
+	path    string // import path of the package declaring the port
+	pkgName string
+	name    string
+}
+
+// planPortExtractions plans a port extraction per adapter package imported
+// by a core package
+func (f *Fixer) planPortExtractions(pkgs []*packages.Package) ([]Fix, []string) {
+	c := f.config
+	coreImport := c.importPath(filepath.Join("internal", "core", c.CoreLogicDir()))
+	adaptersImport := c.importPath(filepath.Join("internal", "adapters"))
+	ports := f.corePorts(pkgs)
+
+	var fixes []Fix
+	var notes []string
+	for _, pkg := range pkgs {
+		// Test variants repeat the files of the package
+		if pkg.ID != pkg.PkgPath || pkg.TypesInfo == nil || !withinPath(pkg.PkgPath, coreImport) {
+			continue
+		}
+
+		byAdapter := make(map[string][]*ast.File)
+		for _, file := range pkg.Syntax {
+			for _, spec := range file.Imports {
+				importPath, err := strconv.Unquote(spec.Path.Value)
+				if err == nil && withinPath(importPath, adaptersImport) {
+					byAdapter[importPath] = append(byAdapter[importPath], file)
+				}
+			}
+		}
+
+		for _, adapter := range slices.Sorted(maps.Keys(byAdapter)) {
+			extraction, err := f.planPortExtraction(pkg, adapter, byAdapter[adapter], pkgs, ports)
+			if err != nil {
+				notes = append(notes, err.Error())
+				continue
+			}
+			fixes = append(fixes, Fix{
+				Rule: "core-dependencies",
+				Description: fmt.Sprintf("Replace the import of %s in %s with the ports %s",
+					adapter, extraction.coreDir, strings.Join(extraction.ports, ", ")),
+				apply: func() error { return f.extractPorts(extraction) },
+			})
+		}
+	}
+	return fixes, notes
+}
+
+// corePorts returns the ports of the core: the exported interfaces with
+// methods declared in internal/core, sorted by package and name
+func (f *Fixer) corePorts(pkgs []*packages.Package) []*types.TypeName {
+	coreImport := f.config.importPath(filepath.Join("internal", "core"))
+
+	var ports []*types.TypeName
+	for _, pkg := range pkgs {
+		if pkg.ID != pkg.PkgPath || pkg.Types == nil || !withinPath(pkg.PkgPath, coreImport) {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() || !obj.Exported() {
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if iface, ok := named.Underlying().(*types.Interface); ok && iface.IsMethodSet() && iface.NumMethods() > 0 {
+				ports = append(ports, obj)
+			}
+		}
+	}
+	slices.SortFunc(ports, func(a, b *types.TypeName) int {
+		return cmp.Or(strings.Compare(a.Pkg().Path(), b.Pkg().Path()), strings.Compare(a.Name(), b.Name()))
+	})
+	return ports
+}
+
+// planPortExtraction plans the extraction of the adapter types files of pkg
+// use into ports. Only types whose methods alone are used can move behind
+// a port: constructing them or reading their fields is left to fix by hand.
+func (f *Fixer) planPortExtraction(pkg *packages.Package, adapter string, files []*ast.File, pkgs []*packages.Package, ports []*types.TypeName) (*portExtraction, error) {
+	c := f.config
+	root, err := filepath.Abs(c.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &portExtraction{
+		coreDir: filepath.FromSlash(strings.TrimPrefix(pkg.PkgPath, c.ModuleName+"/")),
+		adapter: adapter,
+		imports: make(map[string]string),
+		edits:   make(map[string][]analyzer.TextEdit),
+	}
+
+	// New ports go to the domain package named after the core package, or
+	// the domain root
+	domainRoot := filepath.Join("internal", "core", "domain")
+	e.domainDir = filepath.Join(domainRoot, path.Base(pkg.PkgPath))
+	if !c.isDir(e.domainDir) {
+		e.domainDir = domainRoot
+	}
+	domainImport := c.importPath(e.domainDir)
+	e.portFile = filepath.Join(e.domainDir, "port.go")
+
+	var domain *types.Package
+	for _, p := range pkgs {
+		if p.ID == domainImport && p.Types != nil {
+			domain = p.Types
+		}
+	}
+	e.domainName = path.Base(domainImport)
+	if domain != nil {
+		e.domainName = domain.Name()
+	}
+
+	// The uses of the adapter types, by file, and the methods called on them
+	type typeUse struct {
+		node ast.Node // the type expression to replace
+		obj  *types.TypeName
+	}
+	uses := make(map[*ast.File][]typeUse)
+	called := make(map[*types.TypeName]map[string]bool)
+	for _, file := range files {
+		name, ok := relativePath(root, pkg.Fset.File(file.Pos()).Name())
+		if !ok {
+			return nil, fmt.Errorf("%s is outside the project", pkg.Fset.File(file.Pos()).Name())
+		}
+
+		astutil.Apply(file, func(cur *astutil.Cursor) bool {
+			if err != nil {
+				return false
+			}
+			sel, ok := cur.Node().(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			id, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			pkgName, ok := pkg.TypesInfo.Uses[id].(*types.PkgName)
+			if !ok || pkgName.Imported().Path() != adapter {
+				return true
+			}
+
+			line := pkg.Fset.Position(sel.Pos()).Line
+			obj, ok := pkg.TypesInfo.Uses[sel.Sel].(*types.TypeName)
+			if !ok {
+				err = fmt.Errorf("%s:%d: %s.%s is not a type: move its use to the composition root", name, line, id.Name, sel.Sel.Name)
+				return false
+			}
+
+			var node ast.Node = sel
+			parent := cur.Parent()
+			if star, ok := parent.(*ast.StarExpr); ok && star.X == sel {
+				node = star
+				parent = nil
+			}
+			switch parent.(type) {
+			case *ast.CompositeLit, *ast.CallExpr:
+				err = fmt.Errorf("%s:%d: %s.%s is constructed in the core: move it to the composition root", name, line, id.Name, sel.Sel.Name)
+				return false
+			}
+
+			uses[file] = append(uses[file], typeUse{node: node, obj: obj})
+			return false
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// The first field of an adapter type read in the file
+		var field *ast.SelectorExpr
+		var fieldOf *types.Named
+		for expr, selection := range pkg.TypesInfo.Selections {
+			if expr.Pos() < file.Pos() || expr.End() > file.End() {
+				continue
+			}
+			recv := selection.Recv()
+			if ptr, ok := recv.(*types.Pointer); ok {
+				recv = ptr.Elem()
+			}
+			named, ok := recv.(*types.Named)
+			if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != adapter {
+				continue
+			}
+			switch selection.Kind() {
+			case types.FieldVal:
+				if field == nil || expr.Pos() < field.Pos() {
+					field, fieldOf = expr, named
+				}
+			case types.MethodVal:
+				if called[named.Obj()] == nil {
+					called[named.Obj()] = make(map[string]bool)
+				}
+				called[named.Obj()][expr.Sel.Name] = true
+			}
+		}
+		if field != nil {
+			return nil, fmt.Errorf("%s:%d: field %s of %s.%s is used in the core: only methods can move behind a port",
+				name, pkg.Fset.Position(field.Pos()).Line, field.Sel.Name, fieldOf.Obj().Pkg().Name(), fieldOf.Obj().Name())
+		}
+	}
+
+	// A port per adapter type: a port of the core it implements already, or
+	// a new one with its exported methods
+	refs := make(map[*types.TypeName]portRef)
+	for _, file := range files {
+		for _, use := range uses[file] {
+			if _, ok := refs[use.obj]; ok {
+				continue
+			}
+			ref, decl, err := f.port(use.obj, called[use.obj], pkg.Types, ports, domain, e)
+			if err != nil {
+				return nil, err
+			}
+			refs[use.obj] = ref
+			port := strings.TrimPrefix(ref.path, c.ModuleName+"/") + "." + ref.name
+			if !slices.Contains(e.ports, port) {
+				e.ports = append(e.ports, port)
+			}
+			if decl != "" {
+				e.decls = append(e.decls, decl)
+			}
+		}
+	}
+
+	// The edits of the core files
+	domainRootImport := c.importPath(domainRoot)
+	for _, file := range files {
+		name, _ := relativePath(root, pkg.Fset.File(file.Pos()).Name())
+		content, err := c.readFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		offset := func(pos token.Pos) int { return pkg.Fset.Position(pos).Offset }
+
+		// The packages of the ports the file uses, and their aliases when
+		// imported already
+		var needed []portRef
+		for _, use := range uses[file] {
+			ref := refs[use.obj]
+			if ref.path != pkg.PkgPath && !slices.ContainsFunc(needed, func(r portRef) bool { return r.path == ref.path }) {
+				needed = append(needed, ref)
+			}
+		}
+		aliases := make(map[string]string)
+		var adapterSpec *ast.ImportSpec
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			if importPath == adapter {
+				adapterSpec = spec
+				continue
+			}
+			for _, ref := range needed {
+				if ref.path != importPath {
+					continue
+				}
+				aliases[importPath] = ref.pkgName
+				if spec.Name != nil {
+					aliases[importPath] = spec.Name.Name
+				}
+			}
+		}
+
+		// The adapter import is replaced by the missing ones
+		var specs []string
+		for _, ref := range needed {
+			if _, ok := aliases[ref.path]; ok {
+				continue
+			}
+			alias, spec := ref.pkgName, strconv.Quote(ref.path)
+			if withinPath(ref.path, domainRootImport) && ref.path != domainRootImport {
+				// Entity sub-packages are imported as <package>Domain
+				alias += "Domain"
+				spec = alias + " " + spec
+			}
+			aliases[ref.path] = alias
+			specs = append(specs, spec)
+		}
+
+		var edits []analyzer.TextEdit
+		if len(specs) == 0 {
+			edits = append(edits, removeImport(file, adapterSpec, content, offset))
+		} else {
+			edits = append(edits, analyzer.TextEdit{
+				Start: offset(adapterSpec.Pos()),
+				End:   offset(adapterSpec.End()),
+				New:   importSpecs(file, adapterSpec, specs),
+			})
+		}
+
+		for _, use := range uses[file] {
+			ref := refs[use.obj]
+			port := ref.name
+			if ref.path != pkg.PkgPath {
+				port = aliases[ref.path] + "." + ref.name
+			}
+			edits = append(edits, analyzer.TextEdit{
+				Start: offset(use.node.Pos()),
+				End:   offset(use.node.End()),
+				New:   port,
+			})
+		}
+		slices.SortFunc(edits, func(a, b analyzer.TextEdit) int { return a.Start - b.Start })
+		e.edits[name] = edits
+	}
+
+	return e, nil
+}
+
+// port returns the port for the adapter type obj and, when it is a new one
+// of the domain package of e, its declaration. A port of the core obj
+// implements already, with the methods called on it, is reused: the ones
+// named after obj first. The packages the declaration refers to are added
+// to the imports of e.
+func (f *Fixer) port(obj *types.TypeName, called map[string]bool, from *types.Package, ports []*types.TypeName, domain *types.Package, e *portExtraction) (portRef, string, error) {
+	var reused *types.TypeName
+	for _, port := range ports {
+		if !satisfies(obj, port, called) || (port.Pkg() != from && importsPackage(port.Pkg(), from.Path(), make(map[*types.Package]bool))) {
+			continue
+		}
+		if port.Name() == obj.Name() || port.Name() == obj.Name()+"Port" {
+			reused = port
+			break
+		}
+		if reused == nil {
+			reused = port
+		}
+	}
+	if reused != nil {
+		return portRef{path: reused.Pkg().Path(), pkgName: reused.Pkg().Name(), name: reused.Name()}, "", nil
+	}
+
+	domainImport := f.config.importPath(e.domainDir)
+	name := ""
+	for _, candidate := range []string{obj.Name(), obj.Name() + "Port"} {
+		if domain == nil || domain.Scope().Lookup(candidate) == nil {
+			name = candidate
+			break
+		}
+	}
+	if name == "" {
+		return portRef{}, "", fmt.Errorf("%s.%s: %s and %sPort are declared in %s already", obj.Pkg().Name(), obj.Name(), obj.Name(), obj.Name(), domainImport)
+	}
+
+	// The domain may only refer to itself, pkg/ and other modules
+	var err error
+	qualifier := func(p *types.Package) string {
+		switch {
+		case p.Path() == domainImport:
+			return ""
+		case withinPath(p.Path(), f.config.ModuleName) &&
+			!withinPath(p.Path(), f.config.importPath(filepath.Join("internal", "core", "domain"))) &&
+			!withinPath(p.Path(), f.config.importPath("pkg")):
+			err = fmt.Errorf("%s.%s refers to %s, which the domain can't import", obj.Pkg().Name(), obj.Name(), p.Path())
+		}
+		e.imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	recv := obj.Type()
+	if !types.IsInterface(recv) {
+		recv = types.NewPointer(recv)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s defines the port for %s.%s.\n", name, obj.Pkg().Name(), obj.Name())
+	fmt.Fprintf(&b, "type %s interface {\n", name)
+	methods := types.NewMethodSet(recv)
+	exported := 0
+	for i := range methods.Len() {
+		fn := methods.At(i).Obj()
+		if !fn.Exported() {
+			continue
+		}
+		exported++
+		fmt.Fprintf(&b, "\t%s%s\n", fn.Name(), strings.TrimPrefix(types.TypeString(fn.Type(), qualifier), "func"))
+	}
+	b.WriteString("}\n")
+
+	if err != nil {
+		return portRef{}, "", err
+	}
+	if exported == 0 {
+		return portRef{}, "", fmt.Errorf("%s.%s has no exported methods to extract into a port", obj.Pkg().Name(), obj.Name())
+	}
+	return portRef{path: domainImport, pkgName: e.domainName, name: name}, b.String(), nil
+}
+
+// satisfies reports whether the adapter type obj implements port, and port
+// has the methods called on obj
+func satisfies(obj, port *types.TypeName, called map[string]bool) bool {
+	for method := range called {
+		if found, _, _ := types.LookupFieldOrMethod(port.Type(), false, port.Pkg(), method); found == nil {
+			return false
+		}
+	}
+	iface := port.Type().Underlying().(*types.Interface)
+	if types.IsInterface(obj.Type()) {
+		return types.Implements(obj.Type(), iface)
+	}
+	return implements(obj, iface)
+}
+
+// implements reports whether the type of obj, or a pointer to it, has the
+// methods of iface. Unlike types.Implements it needs every method found:
+// embedding a type that failed to load does not make up for a missing one.
+func implements(obj *types.TypeName, iface *types.Interface) bool {
+	t := types.NewPointer(obj.Type())
+	for i := range iface.NumMethods() {
+		method := iface.Method(i)
+		found, _, _ := types.LookupFieldOrMethod(t, false, method.Pkg(), method.Name())
+		fn, ok := found.(*types.Func)
+		if !ok || !types.Identical(fn.Type(), method.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// importsPackage reports whether p imports the package path, directly or
+// not
+func importsPackage(p *types.Package, path string, seen map[*types.Package]bool) bool {
+	if seen[p] {
+		return false
+	}
+	seen[p] = true
+	for _, imported := range p.Imports() {
+		if imported.Path() == path || importsPackage(imported, path, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractPorts declares the new ports of e and rewrites the core files to
+// use them
+func (f *Fixer) extractPorts(e *portExtraction) error {
+	c := f.config
+	recorded := c.recordedFiles()
+
+	if len(e.decls) > 0 {
+		old, _ := c.readFile(e.portFile)
+		content, err := appendPorts(old, e.domainName, e.imports, e.decls)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.portFile, err)
+		}
+		// A generated port file no longer matches its template: its
+		// manifest entry is left to show it as modified
+		f.config.progressf("📝 Writing %s\n", e.portFile)
+		if err := c.writeFile(e.portFile, content); err != nil {
+			return err
+		}
+	}
+
+	return c.editFiles(e.edits, recorded, func(string) bool { return false })
+}
+
+// appendPorts appends decls to the content of a port file, adding their
+// imports. The file is created when content is empty.
+func appendPorts(content []byte, pkgName string, imports map[string]string, decls []string) ([]byte, error) {
+	if len(content) == 0 {
+		content = []byte("package " + pkgName + "\n")
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	for _, importPath := range slices.Sorted(maps.Keys(imports)) {
+		name := imports[importPath]
+		if name == path.Base(importPath) {
+			name = ""
+		}
+		astutil.AddNamedImport(fset, file, name, importPath)
+	}
+
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, file); err != nil {
+		return nil, err
+	}
+	for _, decl := range decls {
+		b.WriteString("\n" + decl)
+	}
+	return format.Source(b.Bytes())
+}
+
+// removeImport returns the edit removing spec from file: its line in an
+// import block, or the whole declaration of a single import
+func removeImport(file *ast.File, spec *ast.ImportSpec, content []byte, offset func(token.Pos) int) analyzer.TextEdit {
+	start, end := offset(spec.Pos()), offset(spec.End())
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && !gen.Lparen.IsValid() && slices.Contains(gen.Specs, ast.Spec(spec)) {
+			start, end = offset(gen.Pos()), offset(gen.End())
+		}
+	}
+	if spec.Doc != nil {
+		start = offset(spec.Doc.Pos())
+	}
+	if spec.Comment != nil {
+		end = offset(spec.Comment.End())
+	}
+
+	// The whole line
+	for start > 0 && content[start-1] != '\n' {
+		start--
+	}
+	if i := bytes.IndexByte(content[end:], '\n'); i >= 0 {
+		end += i + 1
+	}
+	return analyzer.TextEdit{Start: start, End: end}
+}
+
+// importSpecs returns the text replacing spec with specs: one per line in
+// an import block, which a single import becomes when there are more
+func importSpecs(file *ast.File, spec *ast.ImportSpec, specs []string) string {
+	text := strings.Join(specs, "\n\t")
+	if len(specs) == 1 {
+		return text
+	}
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && !gen.Lparen.IsValid() && slices.Contains(gen.Specs, ast.Spec(spec)) {
+			return "(\n\t" + text + "\n)"
+		}
+	}
+	return text
+}
+
+// withinPath reports whether the import path p is dir or inside it
+func withinPath(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
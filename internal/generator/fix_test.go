@@ -0,0 +1,325 @@
+package generator
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestFixer(t *testing.T) {
+	dir := t.TempDir()
+	disk := fsys.NewOS(dir)
+
+	for path, content := range map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.21\n",
+		"internal/core/domain/orders/order.go": `package orders
+
+// Order is a customer order
+type Order struct {
+	ID string
+}
+`,
+		"internal/core/services/orders/orders.go": `package orders
+
+import (
+	"context"
+
+	"example.com/demo/internal/adapters/driven/database/orders"
+	ordersDomain "example.com/demo/internal/core/domain/orders"
+)
+
+type OrderService struct {
+	repo *orders.Repository
+}
+
+func NewOrderService(repo *orders.Repository) *OrderService {
+	return &OrderService{repo: repo}
+}
+
+func (s *OrderService) Place(ctx context.Context, id string) error {
+	return s.repo.Save(ctx, &ordersDomain.Order{ID: id})
+}
+`,
+		"internal/adapters/driven/database/orders/orders.go": `package orders
+
+import (
+	"context"
+
+	ordersDomain "example.com/demo/internal/core/domain/orders"
+)
+
+type Repository struct{}
+
+func New() *Repository {
+	return &Repository{}
+}
+
+func (r *Repository) Save(ctx context.Context, order *ordersDomain.Order) error {
+	return nil
+}
+
+func (r *Repository) table() string {
+	return "orders"
+}
+`,
+		"internal/adapters/primary/http/http.go": "package http\n",
+		"cmd/main.go": `package main
+
+import (
+	"example.com/demo/internal/adapters/driven/database/orders"
+	ordersSvc "example.com/demo/internal/core/services/orders"
+)
+
+func main() {
+	ordersSvc.NewOrderService(orders.New())
+}
+`,
+	} {
+		mustWrite(t, disk, path, content)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.OutputDir = dir
+	config.FS = disk
+	fixer := NewFixer(config)
+
+	var rules []string
+	for {
+		pkgs, err := analyzer.LoadSyntax(dir)
+		if err != nil {
+			t.Fatalf("LoadSyntax() error = %v", err)
+		}
+		fixes, notes := fixer.Plan(pkgs)
+		if len(notes) > 0 {
+			t.Errorf("Plan() notes = %q", notes)
+		}
+		if len(fixes) == 0 {
+			break
+		}
+		if len(rules) == 5 {
+			t.Fatalf("fixes keep coming: %+v", fixes)
+		}
+
+		var b bytes.Buffer
+		if err := fixer.Preview(&b, fixes[0]); err != nil {
+			t.Fatalf("Preview() error = %v", err)
+		}
+		if b.Len() == 0 {
+			t.Errorf("Preview() of %q printed nothing", fixes[0].Description)
+		}
+
+		if err := fixer.Apply(fixes[0]); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		rules = append(rules, fixes[0].Rule)
+	}
+
+	if want := []string{"naming-conventions", "project-structure", "core-dependencies"}; !slices.Equal(rules, want) {
+		t.Errorf("applied fixes = %q, want %q", rules, want)
+	}
+
+	for path, want := range map[string][]string{
+		"internal/core/domain/orders/port.go": {
+			"package orders",
+			`import "context"`,
+			"type Repository interface {\n\tSave(ctx context.Context, order *Order) error\n}",
+		},
+		"internal/core/services/orders/orders.go": {
+			"repo ordersDomain.Repository",
+			"func NewOrderService(repo ordersDomain.Repository) *OrderService {",
+		},
+		"cmd/main.go": {
+			`"example.com/demo/internal/adapters/secondary/database/orders"`,
+		},
+		"internal/adapters/secondary/database/orders/orders.go": {
+			"type Repository struct{}",
+		},
+	} {
+		content := string(mustRead(t, disk, path))
+		for _, w := range want {
+			if !strings.Contains(content, w) {
+				t.Errorf("%s is missing %q:\n%s", path, w, content)
+			}
+		}
+	}
+
+	if content := string(mustRead(t, disk, "internal/core/services/orders/orders.go")); strings.Contains(content, "internal/adapters") {
+		t.Errorf("adapter import left in the service:\n%s", content)
+	}
+	if fsys.Exists(disk, "internal/adapters/driven") {
+		t.Error("internal/adapters/driven not moved")
+	}
+	if !fsys.IsDir(disk, "internal/config") {
+		t.Error("internal/config not created")
+	}
+
+	if result := NewValidator(config).Validate(); len(result.Violations) > 0 {
+		t.Errorf("Validate() violations after fixing = %q", violationStrings(result.Violations))
+	}
+}
+
+func TestFixerPlanUnfixable(t *testing.T) {
+	dir := t.TempDir()
+	disk := fsys.NewOS(dir)
+
+	for path, content := range map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.21\n",
+		"internal/core/services/orders/orders.go": `package orders
+
+import "example.com/demo/internal/adapters/secondary/database/orders"
+
+func NewRepository() *orders.Repository {
+	return orders.New()
+}
+`,
+		"internal/adapters/secondary/database/orders/orders.go": `package orders
+
+type Repository struct{}
+
+func New() *Repository {
+	return &Repository{}
+}
+`,
+	} {
+		mustWrite(t, disk, path, content)
+	}
+
+	pkgs, err := analyzer.LoadSyntax(dir)
+	if err != nil {
+		t.Fatalf("LoadSyntax() error = %v", err)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.OutputDir = dir
+	config.FS = disk
+
+	fixes, notes := NewFixer(config).Plan(pkgs)
+	for _, fix := range fixes {
+		if fix.Rule != "project-structure" {
+			t.Errorf("unexpected fix %q", fix.Description)
+		}
+	}
+	want := "internal/core/services/orders/orders.go:6: orders.New is not a type: move its use to the composition root"
+	if len(notes) != 1 || notes[0] != want {
+		t.Errorf("Plan() notes = %q, want %q", notes, want)
+	}
+}
+
+func TestFixerReusesCorePort(t *testing.T) {
+	dir := t.TempDir()
+	disk := fsys.NewOS(dir)
+
+	for path, content := range map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.21\n",
+		"internal/core/domain/orders/order.go": `package orders
+
+// Order is a customer order
+type Order struct {
+	ID string
+}
+`,
+		"internal/core/ports/orders.go": `package ports
+
+import (
+	"context"
+
+	"example.com/demo/internal/core/domain/orders"
+)
+
+// OrderRepository stores orders
+type OrderRepository interface {
+	Save(ctx context.Context, order *orders.Order) error
+}
+`,
+		"internal/core/services/orders/orders.go": `package orders
+
+import "example.com/demo/internal/adapters/secondary/database/orders"
+
+type OrderService struct {
+	repo   *orders.Repository
+	events *orders.Events
+}
+
+func NewOrderService(repo *orders.Repository, events *orders.Events) *OrderService {
+	return &OrderService{repo: repo, events: events}
+}
+`,
+		"internal/adapters/secondary/database/orders/orders.go": `package orders
+
+import (
+	"context"
+
+	ordersDomain "example.com/demo/internal/core/domain/orders"
+)
+
+type Repository struct{}
+
+func (r *Repository) Save(ctx context.Context, order *ordersDomain.Order) error {
+	return nil
+}
+
+func (r *Repository) Count(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+type Events struct{}
+
+func (e *Events) Publish(ctx context.Context, order *ordersDomain.Order) error {
+	return nil
+}
+`,
+	} {
+		mustWrite(t, disk, path, content)
+	}
+
+	pkgs, err := analyzer.LoadSyntax(dir)
+	if err != nil {
+		t.Fatalf("LoadSyntax() error = %v", err)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.OutputDir = dir
+	config.FS = disk
+	fixer := NewFixer(config)
+
+	fixes, notes := fixer.Plan(pkgs)
+	if len(notes) > 0 {
+		t.Errorf("Plan() notes = %q", notes)
+	}
+	i := slices.IndexFunc(fixes, func(fix Fix) bool { return fix.Rule == "core-dependencies" })
+	if i < 0 {
+		t.Fatalf("Plan() fixes = %+v, want a core-dependencies one", fixes)
+	}
+	if want := "with the ports internal/core/ports.OrderRepository, internal/core/domain/orders.Events"; !strings.HasSuffix(fixes[i].Description, want) {
+		t.Errorf("Description = %q, want it to end with %q", fixes[i].Description, want)
+	}
+	if err := fixer.Apply(fixes[i]); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for path, want := range map[string][]string{
+		"internal/core/services/orders/orders.go": {
+			"import (\n\tordersDomain \"example.com/demo/internal/core/domain/orders\"\n\t\"example.com/demo/internal/core/ports\"\n)",
+			"repo   ports.OrderRepository",
+			"events ordersDomain.Events",
+			"func NewOrderService(repo ports.OrderRepository, events ordersDomain.Events) *OrderService {",
+		},
+		"internal/core/domain/orders/port.go": {
+			"type Events interface {\n\tPublish(ctx context.Context, order *Order) error\n}",
+		},
+	} {
+		content := string(mustRead(t, disk, path))
+		for _, w := range want {
+			if !strings.Contains(content, w) {
+				t.Errorf("%s is missing %q:\n%s", path, w, content)
+			}
+		}
+	}
+	if content := string(mustRead(t, disk, "internal/core/domain/orders/port.go")); strings.Contains(content, "Repository") {
+		t.Errorf("a port duplicating ports.OrderRepository was declared:\n%s", content)
+	}
+}
@@ -0,0 +1,658 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// grpcImport is an import of a generated gRPC Go file
+type grpcImport struct {
+	Alias string
+	Path  string
+}
+
+// grpcField is a field of a generated protobuf message.
+// Fields that cannot be mapped only carry a Todo note.
+type grpcField struct {
+	Label  string // "", "repeated " or "optional "
+	Type   string
+	Name   string
+	Number int
+	Todo   string
+}
+
+// grpcMessage is a generated protobuf message
+type grpcMessage struct {
+	Name    string
+	Comment string
+	Fields  []grpcField
+}
+
+// grpcMethod is one rpc of the generated service and its Go implementation
+type grpcMethod struct {
+	Name           string
+	Request        string
+	Response       string
+	Call           string   // statement calling the core service
+	HasErr         bool     // the core method returns an error
+	ResponseFields []string // key: value lines of the response literal
+}
+
+// protoMapping describes how a Go type travels in a protobuf message
+type protoMapping struct {
+	label     string
+	protoType string
+	usesTime  bool // the Go side needs timestamppb
+	toProto   func(expr string) string
+	fromProto func(expr string) string
+}
+
+// protoScalars maps Go scalar types to their protobuf type and the Go type protoc-gen-go uses for it
+var protoScalars = map[string][2]string{
+	"string":  {"string", "string"},
+	"bool":    {"bool", "bool"},
+	"int":     {"int64", "int64"},
+	"int8":    {"int32", "int32"},
+	"int16":   {"int32", "int32"},
+	"int32":   {"int32", "int32"},
+	"int64":   {"int64", "int64"},
+	"uint":    {"uint64", "uint64"},
+	"uint8":   {"uint32", "uint32"},
+	"byte":    {"uint32", "uint32"},
+	"uint16":  {"uint32", "uint32"},
+	"uint32":  {"uint32", "uint32"},
+	"uint64":  {"uint64", "uint64"},
+	"float32": {"float", "float32"},
+	"float64": {"double", "float64"},
+}
+
+// grpcBuilder derives the protobuf contract and the Go glue for one entity
+type grpcBuilder struct {
+	config     *ProjectConfig
+	entity     *analyzer.DomainStruct
+	structs    []analyzer.DomainStruct
+	portPath   string
+	protoFiles map[string]bool
+	imports    map[string]grpcImport
+	serverTime bool
+}
+
+// GenerateGRPCService generates a gRPC service for an entity: the .proto
+// contract, a server implementation calling the core service and its
+// registration on the gRPC server.
+// port provides the rpc methods; structs resolves request parameter types.
+func (g *AdapterGenerator) GenerateGRPCService(serviceName string, entity *analyzer.DomainStruct, port *analyzer.PortInfo, structs []analyzer.DomainStruct) error {
+	return g.config.transact(func() error {
+		return g.generateGRPCService(serviceName, entity, port, structs)
+	})
+}
+
+// generateGRPCService does the work of GenerateGRPCService inside its transaction
+func (g *AdapterGenerator) generateGRPCService(serviceName string, entity *analyzer.DomainStruct, port *analyzer.PortInfo, structs []analyzer.DomainStruct) error {
+	pkgName := utils.ToPlural(strings.ToLower(entity.Name))
+	grpcDir := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "grpc")
+	adapterDir := filepath.Join(grpcDir, pkgName)
+	protoDir := filepath.Join("api", "proto", pkgName, "v1")
+	protoFile := filepath.Join(protoDir, pkgName+".proto")
+	serverFile := filepath.Join(adapterDir, "server.go")
+	mapperFile := filepath.Join(adapterDir, "mapper.go")
+
+	if g.config.fileExists(serverFile) {
+		return fmt.Errorf("gRPC service file %s already exists", serverFile)
+	}
+
+	serviceDir := filepath.Join("internal", "core", g.config.CoreLogicDir(), pkgName)
+	if !g.config.fileExists(serviceDir) {
+		fmt.Printf("⚠️  Warning: %s not found; the handler expects a Services.%s field (hexago add service %sService --entity %s)\n",
+			serviceDir, utils.ToTitleCase(pkgName), entity.Name, entity.Name)
+	}
+
+	if err := g.EnsureDomainError("ErrNotFound", "entity not found"); err != nil {
+		return err
+	}
+
+	b := &grpcBuilder{
+		config:     g.config,
+		entity:     entity,
+		structs:    structs,
+		portPath:   port.ImportPath,
+		protoFiles: map[string]bool{},
+		imports:    map[string]grpcImport{},
+	}
+
+	pbImport := fmt.Sprintf("%s/gen/proto/%s/v1", g.config.ModuleName, pkgName)
+	pbAlias := pkgName + "v1"
+
+	// The core dependency: the port interface, or the concrete service
+	manageType := b.qualify(port.ImportPath, port.Name)
+	if !b.isInterfacePort(port) {
+		manageType = "*" + manageType
+	}
+
+	entityMessage, toProto, fromProto, entityTime := b.entityMessage()
+	messages := []grpcMessage{entityMessage}
+
+	var methods []grpcMethod
+	for _, m := range port.Methods {
+		method, reqMsg, respMsg, err := b.method(m)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: skipping rpc %s: %v\n", m.Name, err)
+			continue
+		}
+		methods = append(methods, method)
+		messages = append(messages, reqMsg, respMsg)
+	}
+	if len(methods) == 0 {
+		fmt.Printf("⚠️  Warning: no method of %s could be mapped to an rpc\n", port.Name)
+	}
+
+	var protoImports []string
+	for file := range b.protoFiles {
+		protoImports = append(protoImports, file)
+	}
+	sort.Strings(protoImports)
+
+	// Proto contract
+	if err := g.config.createDirs(".", []string{protoDir, adapterDir}); err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 Creating proto file: %s\n", protoFile)
+	protoData := map[string]any{
+		"ProtoPackage": pkgName + ".v1",
+		"GoPackage":    pbImport + ";" + pbAlias,
+		"ServiceName":  serviceName,
+		"EntityName":   entity.Name,
+		"Source":       port.Name,
+		"Imports":      protoImports,
+		"Methods":      methods,
+		"Messages":     messages,
+	}
+	if err := g.renderFile("adapter/primary/grpc/service.proto.tmpl", protoFile, protoData, false); err != nil {
+		return err
+	}
+
+	// Server implementation
+	if b.serverTime {
+		b.addImport("google.golang.org/protobuf/types/known/timestamppb", "")
+	}
+	root := strings.TrimSuffix(strings.Repeat("../", strings.Count(adapterDir, string(filepath.Separator))+1), "/")
+	generate := fmt.Sprintf("protoc -I %[1]s/api/proto --go_out=%[1]s --go_opt=module=%[2]s --go-grpc_out=%[1]s --go-grpc_opt=module=%[2]s %[3]s/v1/%[3]s.proto",
+		root, g.config.ModuleName, pkgName)
+
+	fmt.Printf("📝 Creating gRPC server file: %s\n", serverFile)
+	serverData := map[string]any{
+		"PackageName":  pkgName,
+		"ModuleName":   g.config.ModuleName,
+		"CoreLogic":    g.config.CoreLogicDir(),
+		"ServiceName":  serviceName,
+		"ServiceField": utils.ToTitleCase(pkgName),
+		"ManageType":   manageType,
+		"PbAlias":      pbAlias,
+		"PbImport":     pbImport,
+		"Imports":      b.sortedImports(),
+		"Generate":     generate,
+		"Methods":      methods,
+	}
+	if err := g.renderFile("adapter/primary/grpc/grpc_server.go.tmpl", serverFile, serverData, true); err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 Creating gRPC mapper file: %s\n", mapperFile)
+	mapperData := map[string]any{
+		"PackageName":  pkgName,
+		"ModuleName":   g.config.ModuleName,
+		"EntityName":   entity.Name,
+		"EntityAlias":  b.alias(entity.ImportPath),
+		"EntityImport": entity.ImportPath,
+		"PbAlias":      pbAlias,
+		"PbImport":     pbImport,
+		"UsesTime":     entityTime,
+		"ToProto":      toProto,
+		"FromProto":    fromProto,
+	}
+	if err := g.renderFile("adapter/primary/grpc/grpc_mapper.go.tmpl", mapperFile, mapperData, true); err != nil {
+		return err
+	}
+
+	// Shared server and registration
+	if err := g.ensureGRPCServer(); err != nil {
+		return err
+	}
+
+	return g.upsertGRPCAdapter(grpcDir)
+}
+
+// renderFile renders a template into path, gofmt-ing Go output
+func (g *AdapterGenerator) renderFile(tmpl, path string, data any, isGo bool) error {
+	content, err := g.config.templateLoader.Render(tmpl, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", tmpl, err)
+	}
+
+	if isGo {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", path, err)
+		}
+		content = formatted
+	}
+
+	return g.config.writeGenerated(path, tmpl, content)
+}
+
+// ensureGRPCServer creates pkg/server and pkg/grpcserver when missing
+func (g *AdapterGenerator) ensureGRPCServer() error {
+	files := []struct{ source, target string }{
+		{"pkg/server/server_interface.go.tmpl", filepath.Join("pkg", "server", "server.go")},
+		{"pkg/grpcserver/grpc_server.go.tmpl", filepath.Join("pkg", "grpcserver", "server.go")},
+	}
+
+	for _, f := range files {
+		if g.config.fileExists(f.target) {
+			continue
+		}
+		if err := g.config.createDir(filepath.Dir(f.target)); err != nil {
+			return err
+		}
+
+		fmt.Printf("📝 Creating %s\n", f.target)
+		content, err := g.config.templateLoader.Render(f.source, g.config)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", f.source, err)
+		}
+		if err := g.config.writeGenerated(f.target, f.source, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertGRPCAdapter regenerates grpc.go from the service sub-packages in grpcDir
+func (g *AdapterGenerator) upsertGRPCAdapter(grpcDir string) error {
+	entries, err := g.config.readDirNames(grpcDir)
+	if err != nil {
+		return fmt.Errorf("reading gRPC adapter dir: %w", err)
+	}
+
+	var packages []string
+	for _, name := range entries {
+		if g.config.fileExists(filepath.Join(grpcDir, name, "server.go")) {
+			packages = append(packages, name)
+		}
+	}
+	sort.Strings(packages)
+
+	adapterFile := filepath.Join(grpcDir, "grpc.go")
+	data := map[string]any{
+		"ModuleName":        g.config.ModuleName,
+		"AdapterStyle":      g.config.AdapterStyle,
+		"AdapterInboundDir": g.config.AdapterInboundDir(),
+		"CoreLogic":         g.config.CoreLogicDir(),
+		"Packages":          packages,
+	}
+
+	fmt.Printf("📝 Updating gRPC adapter: %s\n", adapterFile)
+	if err := g.renderFile("adapter/primary/grpc/grpc_adapter.go.tmpl", adapterFile, data, true); err != nil {
+		return err
+	}
+
+	// The composition root starts the gRPC server
+	return g.config.rewire()
+}
+
+// isInterfacePort reports whether port was read from an interface rather
+// than from a concrete type's method set
+func (b *grpcBuilder) isInterfacePort(port *analyzer.PortInfo) bool {
+	for _, s := range b.structs {
+		if s.Name == port.Name && s.ImportPath == port.ImportPath {
+			return false
+		}
+	}
+	return true
+}
+
+// entityMessage builds the entity message and the field mappings of mapper.go
+func (b *grpcBuilder) entityMessage() (msg grpcMessage, toProto, fromProto []string, usesTime bool) {
+	msg = grpcMessage{
+		Name:    b.entity.Name,
+		Comment: fmt.Sprintf("%s mirrors the %s.%s domain entity.", b.entity.Name, b.entity.Package, b.entity.Name),
+	}
+
+	number := 1
+	for _, f := range b.entity.Fields {
+		if !isExported(f.Name) {
+			continue
+		}
+
+		m, ok := b.mapType(f.Type)
+		if !ok {
+			msg.Fields = append(msg.Fields, grpcField{Todo: fmt.Sprintf("map field %s (%s)", f.Name, f.Type)})
+			continue
+		}
+
+		name := snakeCaseName(f.Name)
+		msg.Fields = append(msg.Fields, grpcField{Label: m.label, Type: m.protoType, Name: name, Number: number})
+		number++
+
+		toProto = append(toProto, fmt.Sprintf("%s: %s", goCamelCase(name), m.toProto("e."+f.Name)))
+		fromProto = append(fromProto, fmt.Sprintf("%s: %s", f.Name, m.fromProto("m."+goCamelCase(name))))
+		usesTime = usesTime || m.usesTime
+	}
+
+	return msg, toProto, fromProto, usesTime
+}
+
+// method maps one port method to an rpc with its request and response messages
+func (b *grpcBuilder) method(m analyzer.MethodInfo) (grpcMethod, grpcMessage, grpcMessage, error) {
+	method := grpcMethod{
+		Name:     m.Name,
+		Request:  m.Name + "Request",
+		Response: m.Name + "Response",
+	}
+	req := grpcMessage{Name: method.Request}
+	resp := grpcMessage{Name: method.Response}
+	serverTime := false
+
+	// Request: every parameter but the context becomes one or more fields
+	var args []string
+	for i, p := range m.Params {
+		if p.Type == "context.Context" {
+			args = append(args, "ctx")
+			continue
+		}
+
+		if mp, ok := b.mapType(p.Type); ok {
+			name := snakeCaseName(p.Name)
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			req.Fields = append(req.Fields, grpcField{Label: mp.label, Type: mp.protoType, Name: name, Number: countMapped(req.Fields) + 1})
+			args = append(args, mp.fromProto("req."+goCamelCase(name)))
+			continue
+		}
+
+		s := b.findStruct(strings.TrimPrefix(p.Type, "*"))
+		if s == nil {
+			return method, req, resp, fmt.Errorf("parameter type %s has no protobuf mapping", p.Type)
+		}
+
+		// Flatten input structs into the request
+		var values []string
+		for _, f := range s.Fields {
+			if !isExported(f.Name) {
+				continue
+			}
+			mp, ok := b.mapType(f.Type)
+			if !ok {
+				req.Fields = append(req.Fields, grpcField{Todo: fmt.Sprintf("map %s.%s (%s)", s.Name, f.Name, f.Type)})
+				continue
+			}
+			name := snakeCaseName(f.Name)
+			req.Fields = append(req.Fields, grpcField{Label: mp.label, Type: mp.protoType, Name: name, Number: countMapped(req.Fields) + 1})
+			values = append(values, fmt.Sprintf("%s: %s", f.Name, mp.fromProto("req."+goCamelCase(name))))
+		}
+
+		literal := fmt.Sprintf("%s{%s}", b.qualify(s.ImportPath, s.Name), strings.Join(values, ", "))
+		if strings.HasPrefix(p.Type, "*") {
+			literal = "&" + literal
+		}
+		args = append(args, literal)
+	}
+
+	// Response: every result but the trailing error becomes a field
+	results := len(m.Returns)
+	if results > 0 && m.Returns[results-1].Type == "error" {
+		results--
+	}
+
+	var vars []string
+	for i, r := range m.Returns {
+		if r.Type == "error" && i == len(m.Returns)-1 {
+			method.HasErr = true
+			continue
+		}
+
+		mp, ok := b.mapType(r.Type)
+		if !ok {
+			return method, req, resp, fmt.Errorf("result type %s has no protobuf mapping", r.Type)
+		}
+
+		name := snakeCaseName(r.Name)
+		if name == "" {
+			name = b.resultName(r.Type)
+		}
+		if countMapped(resp.Fields) > 0 {
+			name = fmt.Sprintf("%s_%d", name, i)
+		}
+
+		v := "res"
+		if results > 1 {
+			v = fmt.Sprintf("res%d", i)
+		}
+		vars = append(vars, v)
+
+		resp.Fields = append(resp.Fields, grpcField{Label: mp.label, Type: mp.protoType, Name: name, Number: countMapped(resp.Fields) + 1})
+		method.ResponseFields = append(method.ResponseFields, fmt.Sprintf("%s: %s", goCamelCase(name), mp.toProto(v)))
+		// Only the response side needs timestamppb (requests use AsTime)
+		serverTime = serverTime || mp.usesTime
+	}
+	if method.HasErr {
+		vars = append(vars, "err")
+	}
+
+	method.Call = fmt.Sprintf("h.manage.%s(%s)", m.Name, strings.Join(args, ", "))
+	if len(vars) > 0 {
+		method.Call = strings.Join(vars, ", ") + " := " + method.Call
+	}
+
+	b.serverTime = b.serverTime || serverTime
+	return method, req, resp, nil
+}
+
+// resultName names a response field after its type
+func (b *grpcBuilder) resultName(goType string) string {
+	entity := snakeCaseName(b.entity.Name)
+	switch goType {
+	case b.entity.Name, "*" + b.entity.Name:
+		return entity
+	case "[]*" + b.entity.Name:
+		return utils.ToPlural(entity)
+	}
+	return "result"
+}
+
+// mapType returns how goType is carried in protobuf, if it can be
+func (b *grpcBuilder) mapType(goType string) (protoMapping, bool) {
+	ident := func(e string) string { return e }
+	entity := b.entity.Name
+
+	if scalar, ok := protoScalars[goType]; ok {
+		m := protoMapping{protoType: scalar[0], toProto: ident, fromProto: ident}
+		if scalar[1] != goType {
+			m.toProto = func(e string) string { return scalar[1] + "(" + e + ")" }
+			m.fromProto = func(e string) string { return goType + "(" + e + ")" }
+		}
+		return m, true
+	}
+
+	switch goType {
+	case "Time":
+		b.protoFiles["google/protobuf/timestamp.proto"] = true
+		return protoMapping{
+			protoType: "google.protobuf.Timestamp",
+			usesTime:  true,
+			toProto:   func(e string) string { return "timestamppb.New(" + e + ")" },
+			fromProto: func(e string) string { return e + ".AsTime()" },
+		}, true
+	case "[]byte", "[]uint8":
+		return protoMapping{protoType: "bytes", toProto: ident, fromProto: ident}, true
+	case entity:
+		return protoMapping{
+			protoType: entity,
+			toProto:   func(e string) string { return "toProto" + entity + "(&" + e + ")" },
+			fromProto: func(e string) string { return "*fromProto" + entity + "(" + e + ")" },
+		}, true
+	case "*" + entity:
+		return protoMapping{
+			protoType: entity,
+			toProto:   func(e string) string { return "toProto" + entity + "(" + e + ")" },
+			fromProto: func(e string) string { return "fromProto" + entity + "(" + e + ")" },
+		}, true
+	case "[]*" + entity:
+		return protoMapping{
+			label:     "repeated ",
+			protoType: entity,
+			toProto:   func(e string) string { return "toProto" + entity + "List(" + e + ")" },
+			fromProto: func(e string) string { return "fromProto" + entity + "List(" + e + ")" },
+		}, true
+	}
+
+	// Slices and pointers of scalars protoc-gen-go represents with the same Go type
+	if elem, ok := strings.CutPrefix(goType, "[]"); ok {
+		if scalar, ok := protoScalars[elem]; ok && scalar[1] == elem {
+			return protoMapping{label: "repeated ", protoType: scalar[0], toProto: ident, fromProto: ident}, true
+		}
+	}
+	if elem, ok := strings.CutPrefix(goType, "*"); ok {
+		if scalar, ok := protoScalars[elem]; ok && scalar[1] == elem {
+			return protoMapping{label: "optional ", protoType: scalar[0], toProto: ident, fromProto: ident}, true
+		}
+	}
+
+	return protoMapping{}, false
+}
+
+// findStruct looks a struct up by name, preferring the port's package
+func (b *grpcBuilder) findStruct(name string) *analyzer.DomainStruct {
+	var found *analyzer.DomainStruct
+	for i := range b.structs {
+		s := &b.structs[i]
+		if s.Name != name {
+			continue
+		}
+		if s.ImportPath == b.portPath {
+			return s
+		}
+		if found == nil {
+			found = s
+		}
+	}
+	return found
+}
+
+// qualify returns alias.name for a type of importPath, recording the import
+func (b *grpcBuilder) qualify(importPath, name string) string {
+	alias := b.alias(importPath)
+	b.addImport(importPath, alias)
+	return alias + "." + name
+}
+
+// alias returns the import alias used for a core package,
+// following the <pkg>Domain / <pkg>Svc convention of the HTTP handlers
+func (b *grpcBuilder) alias(importPath string) string {
+	coreLogic := b.config.ModuleName + "/internal/core/" + b.config.CoreLogicDir()
+	base := path.Base(importPath)
+	switch {
+	case importPath == coreLogic:
+		return base
+	case strings.Contains(importPath, "/internal/core/domain/"):
+		return base + "Domain"
+	case strings.Contains(importPath, "/internal/core/"):
+		return base + "Svc"
+	}
+	return base
+}
+
+// addImport records an import of server.go; the core logic package is always imported
+func (b *grpcBuilder) addImport(importPath, alias string) {
+	if importPath == b.config.ModuleName+"/internal/core/"+b.config.CoreLogicDir() {
+		return
+	}
+	b.imports[importPath] = grpcImport{Alias: alias, Path: importPath}
+}
+
+// sortedImports returns the recorded imports ordered by path
+func (b *grpcBuilder) sortedImports() []grpcImport {
+	imports := make([]grpcImport, 0, len(b.imports))
+	for _, imp := range b.imports {
+		imports = append(imports, imp)
+	}
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Path < imports[j].Path })
+	return imports
+}
+
+// countMapped counts the fields that are not TODO notes
+func countMapped(fields []grpcField) int {
+	n := 0
+	for _, f := range fields {
+		if f.Todo == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// isExported reports whether a Go identifier is exported
+func isExported(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z'
+}
+
+// snakeCaseName converts a Go identifier to a snake_case field name,
+// keeping acronyms together (UserID → user_id, HTTPCode → http_code)
+func snakeCaseName(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prev := rune(s[i-1])
+			prevLower := (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9')
+			nextLower := i+1 < len(s) && s[i+1] >= 'a' && s[i+1] <= 'z'
+			if prevLower || (prev >= 'A' && prev <= 'Z' && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// goCamelCase returns the Go field name protoc-gen-go generates for a proto field
+func goCamelCase(s string) string {
+	isLower := func(c byte) bool { return c >= 'a' && c <= 'z' }
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}"
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}"
+		case isDigit(c):
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestProtoNames(t *testing.T) {
+	tests := []struct {
+		goName    string
+		protoName string
+		goCamel   string
+	}{
+		{"Name", "name", "Name"},
+		{"ID", "id", "Id"},
+		{"UserID", "user_id", "UserId"},
+		{"CreatedAt", "created_at", "CreatedAt"},
+		{"HTTPCode", "http_code", "HttpCode"},
+		{"Line2", "line2", "Line2"},
+	}
+
+	for _, tt := range tests {
+		if got := snakeCaseName(tt.goName); got != tt.protoName {
+			t.Errorf("snakeCaseName(%q) = %q, want %q", tt.goName, got, tt.protoName)
+		}
+		if got := goCamelCase(tt.protoName); got != tt.goCamel {
+			t.Errorf("goCamelCase(%q) = %q, want %q", tt.protoName, got, tt.goCamel)
+		}
+	}
+}
+
+func TestAdapterGeneratorGenerateGRPCService(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+
+	entity := &analyzer.DomainStruct{
+		Name:       "Order",
+		Package:    "orders",
+		ImportPath: "example.com/demo/internal/core/domain/orders",
+		Fields: []analyzer.FieldInfo{
+			{Name: "ID", Type: "string"},
+			{Name: "Total", Type: "float64"},
+			{Name: "Quantity", Type: "int"},
+			{Name: "PlacedAt", Type: "Time"},
+			{Name: "Lines", Type: "map[string]int"},
+		},
+	}
+	input := analyzer.DomainStruct{
+		Name:       "PlaceOrderInput",
+		ImportPath: "example.com/demo/internal/core/services/orders",
+		Fields:     []analyzer.FieldInfo{{Name: "Quantity", Type: "int"}},
+	}
+	port := &analyzer.PortInfo{
+		Name:       "OrderService",
+		Package:    "orders",
+		ImportPath: "example.com/demo/internal/core/services/orders",
+		Methods: []analyzer.MethodInfo{
+			{
+				Name:    "Place",
+				Params:  []analyzer.ParamInfo{{Name: "ctx", Type: "context.Context"}, {Name: "input", Type: "PlaceOrderInput"}},
+				Returns: []analyzer.ParamInfo{{Type: "*Order"}, {Type: "error"}},
+			},
+			{
+				Name:    "List",
+				Params:  []analyzer.ParamInfo{{Name: "ctx", Type: "context.Context"}},
+				Returns: []analyzer.ParamInfo{{Type: "[]*Order"}, {Type: "error"}},
+			},
+			{
+				Name:    "Search",
+				Params:  []analyzer.ParamInfo{{Name: "filter", Type: "map[string]string"}},
+				Returns: []analyzer.ParamInfo{{Type: "[]*Order"}},
+			},
+		},
+	}
+
+	gen := NewAdapterGenerator(config)
+	if err := gen.GenerateGRPCService("OrderService", entity, port, []analyzer.DomainStruct{*entity, input}); err != nil {
+		t.Fatalf("GenerateGRPCService() error = %v", err)
+	}
+
+	contains := map[string][]string{
+		"api/proto/orders/v1/orders.proto": {
+			`package orders.v1;`,
+			`import "google/protobuf/timestamp.proto";`,
+			`rpc Place(PlaceRequest) returns (PlaceResponse);`,
+			`google.protobuf.Timestamp placed_at = 4;`,
+			`// TODO: map field Lines (map[string]int)`,
+			`repeated Order orders = 1;`,
+		},
+		"internal/adapters/primary/grpc/orders/server.go": {
+			`manage ordersSvc.OrderService`,
+			`h.manage.Place(ctx, ordersSvc.PlaceOrderInput{Quantity: int(req.Quantity)})`,
+			`Orders: toProtoOrderList(res)`,
+		},
+		"internal/adapters/primary/grpc/orders/mapper.go": {
+			`PlacedAt: m.PlacedAt.AsTime(),`,
+			`Quantity: int64(e.Quantity),`,
+		},
+		"internal/adapters/primary/grpc/grpc.go": {
+			`srv.Use(orders.New(&orders.Config{Services: services}))`,
+		},
+		"pkg/grpcserver/server.go": {`reflection.Register(s.GRPC)`},
+		"pkg/server/server.go":     {`type ServerHandler interface`},
+	}
+
+	for path, wants := range contains {
+		content, err := mem.ReadFile(path)
+		if err != nil {
+			t.Errorf("expected %s to be generated: %v", path, err)
+			continue
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s does not contain %q", path, want)
+			}
+		}
+	}
+
+	proto, _ := mem.ReadFile("api/proto/orders/v1/orders.proto")
+	if strings.Contains(string(proto), "rpc Search") {
+		t.Errorf("Search has no protobuf mapping and should be skipped")
+	}
+}
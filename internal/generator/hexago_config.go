@@ -18,9 +18,13 @@ const hexagoConfigHeader = `# .hexago.yaml - HexaGo project configuration
 
 // HexagoConfig is the top-level structure for .hexago.yaml
 type HexagoConfig struct {
-	Project   HexagoProjectConfig   `yaml:"project"`
-	Structure HexagoStructureConfig `yaml:"structure"`
-	Features  HexagoFeaturesConfig  `yaml:"features"`
+	Project      HexagoProjectConfig      `yaml:"project"`
+	Structure    HexagoStructureConfig    `yaml:"structure"`
+	Features     HexagoFeaturesConfig     `yaml:"features"`
+	Database     HexagoDatabaseConfig     `yaml:"database"`
+	Migrations   HexagoMigrationsConfig   `yaml:"migrations"`
+	Architecture HexagoArchitectureConfig `yaml:"architecture,omitempty"`
+	Generated    HexagoGeneratedConfig    `yaml:"generated,omitempty"`
 }
 
 // HexagoProjectConfig holds basic project metadata
@@ -50,6 +54,69 @@ type HexagoFeaturesConfig struct {
 	WithExample       bool `yaml:"with_example"`
 }
 
+// HexagoDatabaseConfig holds the SQL database settings
+type HexagoDatabaseConfig struct {
+	Dialect string `yaml:"dialect"`
+	Driver  string `yaml:"driver"`
+}
+
+// HexagoMigrationsConfig holds the migration settings
+type HexagoMigrationsConfig struct {
+	Versioning string `yaml:"versioning"`
+}
+
+// HexagoArchitectureConfig declares the layers of the project and the rules
+// their imports are checked against by hexago validate. The defaults of
+// defaultArchitecture apply to whichever of the two is not declared.
+type HexagoArchitectureConfig struct {
+	Layers []HexagoLayer `yaml:"layers,omitempty"`
+	Rules  []HexagoRule  `yaml:"rules,omitempty"`
+}
+
+// HexagoLayer is a named set of directories, relative to the project root.
+// A package belongs to the layer with the longest path containing it.
+type HexagoLayer struct {
+	Name  string   `yaml:"name"`
+	Paths []string `yaml:"paths"`
+}
+
+// HexagoRule restricts the project packages a layer may import, either to
+// the layers of MayImport or to any layer but those of MayNotImport.
+// Imports within the layer and of packages in no layer are always allowed.
+//
+// AllowExternal and DenyExternal restrict the packages of other modules and
+// of the standard library the layer may import: only the ones matching
+// AllowExternal, when set, and none matching DenyExternal. A pattern
+// matches a package path and the packages below it; "std" matches the
+// standard library.
+type HexagoRule struct {
+	ID            string   `yaml:"id"`
+	Description   string   `yaml:"description,omitempty"`
+	Layer         string   `yaml:"layer"`
+	MayImport     []string `yaml:"may_import,omitempty"`
+	MayNotImport  []string `yaml:"may_not_import,omitempty"`
+	AllowExternal []string `yaml:"allow_external,omitempty"`
+	DenyExternal  []string `yaml:"deny_external,omitempty"`
+	Severity      string   `yaml:"severity,omitempty"` // "error" (default) or "warning"
+}
+
+// HexagoGeneratedConfig is the manifest of the files hexago generated, so
+// hexago status can tell which were edited and hexago upgrade can merge the
+// output of newer templates into them. Version is the hexago version that
+// generated the project files, whose content is kept in .hexago/base/.
+type HexagoGeneratedConfig struct {
+	Version string                `yaml:"version"`
+	Files   []HexagoGeneratedFile `yaml:"files"`
+}
+
+// HexagoGeneratedFile is a file rendered from a template
+type HexagoGeneratedFile struct {
+	Path     string `yaml:"path"`
+	Template string `yaml:"template"`
+	Source   string `yaml:"source"` // template source, as reported by TemplateLoader.Which
+	Hash     string `yaml:"hash"`
+}
+
 // HexagoConfigFromProject maps a ProjectConfig to a HexagoConfig.
 func HexagoConfigFromProject(cfg *ProjectConfig) *HexagoConfig {
 	return &HexagoConfig{
@@ -74,6 +141,13 @@ func HexagoConfigFromProject(cfg *ProjectConfig) *HexagoConfig {
 			WithMetrics:       cfg.WithMetrics,
 			WithExample:       cfg.WithExample,
 		},
+		Database: HexagoDatabaseConfig{
+			Dialect: cfg.DBDialect,
+			Driver:  cfg.DBDriver,
+		},
+		Migrations: HexagoMigrationsConfig{
+			Versioning: cfg.MigrationVersioning,
+		},
 	}
 }
 
@@ -100,9 +174,30 @@ func (h *HexagoConfig) ToProjectConfig() *ProjectConfig {
 	cfg.WithMetrics = h.Features.WithMetrics
 	cfg.WithExample = h.Features.WithExample
 
+	// Files written before the database section keep the defaults
+	if h.Database.Dialect != "" {
+		cfg.DBDialect = h.Database.Dialect
+	}
+	if h.Database.Driver != "" {
+		cfg.DBDriver = h.Database.Driver
+	}
+	if h.Migrations.Versioning != "" {
+		cfg.MigrationVersioning = h.Migrations.Versioning
+	}
+
 	return cfg
 }
 
+// parseHexagoConfig parses the content of .hexago.yaml
+func parseHexagoConfig(data []byte) (*HexagoConfig, error) {
+	var cfg HexagoConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", HexagoConfigFile, err)
+	}
+
+	return &cfg, nil
+}
+
 // LoadHexagoConfig reads and parses {dir}/.hexago.yaml.
 // Returns an error if the file does not exist or cannot be parsed.
 func LoadHexagoConfig(dir string) (*HexagoConfig, error) {
@@ -113,26 +208,29 @@ func LoadHexagoConfig(dir string) (*HexagoConfig, error) {
 		return nil, fmt.Errorf("read %s: %w", HexagoConfigFile, err)
 	}
 
-	var cfg HexagoConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("parse %s: %w", HexagoConfigFile, err)
+	return parseHexagoConfig(data)
+}
+
+// MarshalHexagoConfig serializes cfg, prepending a comment header.
+func MarshalHexagoConfig(cfg *HexagoConfig) ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", HexagoConfigFile, err)
 	}
 
-	return &cfg, nil
+	return []byte(hexagoConfigHeader + string(data)), nil
 }
 
 // SaveHexagoConfig serializes cfg and writes it to {dir}/.hexago.yaml,
 // prepending a comment header.
 func SaveHexagoConfig(dir string, cfg *HexagoConfig) error {
-	data, err := yaml.Marshal(cfg)
+	content, err := MarshalHexagoConfig(cfg)
 	if err != nil {
-		return fmt.Errorf("marshal %s: %w", HexagoConfigFile, err)
+		return err
 	}
 
-	content := hexagoConfigHeader + string(data)
-
 	path := filepath.Join(dir, HexagoConfigFile)
-	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+	if err := os.WriteFile(path, content, 0o644); err != nil {
 		return fmt.Errorf("write %s: %w", HexagoConfigFile, err)
 	}
 
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+)
+
+// hashContent returns the content hash recorded in .hexago.yaml
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// manifestEntry describes a file rendered from the named template
+func (c *ProjectConfig) manifestEntry(path, template string, content []byte) HexagoGeneratedFile {
+	source, err := c.templateLoader.Which(template)
+	if err != nil {
+		source = "unknown"
+	}
+
+	return HexagoGeneratedFile{
+		Path:     path,
+		Template: template,
+		Source:   source,
+		Hash:     hashContent(content),
+	}
+}
+
+// recordFile adds a rendered file to the manifest saved when the current
+// transaction succeeds
+func (c *ProjectConfig) recordFile(path, template string, content []byte) {
+	c.manifest = slices.DeleteFunc(c.manifest, func(f HexagoGeneratedFile) bool { return f.Path == path })
+	c.manifest = append(c.manifest, c.manifestEntry(path, template, content))
+}
+
+// unrecordFile drops a removed file from the manifest saved when the
+// current transaction succeeds
+func (c *ProjectConfig) unrecordFile(path string) {
+	c.manifest = slices.DeleteFunc(c.manifest, func(f HexagoGeneratedFile) bool { return f.Path == path })
+	c.unrecorded = append(c.unrecorded, path)
+}
+
+// recordedFiles maps the paths in the manifest of .hexago.yaml to their
+// entry. It returns nil when the project has no manifest.
+func (c *ProjectConfig) recordedFiles() map[string]HexagoGeneratedFile {
+	content, err := c.readFile(HexagoConfigFile)
+	if err != nil {
+		return nil
+	}
+	hexCfg, err := parseHexagoConfig(content)
+	if err != nil || len(hexCfg.Generated.Files) == 0 {
+		return nil
+	}
+
+	files := make(map[string]HexagoGeneratedFile, len(hexCfg.Generated.Files))
+	for _, f := range hexCfg.Generated.Files {
+		files[f.Path] = f
+	}
+	return files
+}
+
+// recordedHashes maps the files in the manifest of .hexago.yaml to their
+// content hash. It returns nil when the project has no manifest.
+func (c *ProjectConfig) recordedHashes() map[string]string {
+	files := c.recordedFiles()
+	if files == nil {
+		return nil
+	}
+
+	hashes := make(map[string]string, len(files))
+	for path, f := range files {
+		hashes[path] = f.Hash
+	}
+	return hashes
+}
+
+// moveRecord moves the manifest entry of a file hexago rewrote from old to
+// content and, when to differs from from, moved. A file untouched since it
+// was generated stays untouched; an edited one keeps showing as modified.
+func (c *ProjectConfig) moveRecord(recorded map[string]HexagoGeneratedFile, from, to string, old, content []byte) {
+	entry, ok := recorded[from]
+	if !ok {
+		return
+	}
+	if from != to {
+		c.unrecordFile(from)
+	}
+
+	if entry.Hash == hashContent(old) {
+		entry.Hash = hashContent(content)
+	}
+	entry.Path = to
+	c.manifest = slices.DeleteFunc(c.manifest, func(f HexagoGeneratedFile) bool { return f.Path == to })
+	c.manifest = append(c.manifest, entry)
+}
+
+// saveManifest merges the files rendered and removed in this transaction
+// into the manifest of .hexago.yaml. Projects without .hexago.yaml have no
+// manifest.
+func (c *ProjectConfig) saveManifest() error {
+	manifest, unrecorded := c.manifest, c.unrecorded
+	c.manifest, c.unrecorded = nil, nil
+
+	if len(manifest)+len(unrecorded) == 0 || !c.fileExists(HexagoConfigFile) {
+		return nil
+	}
+
+	content, err := c.readFile(HexagoConfigFile)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", HexagoConfigFile, err)
+	}
+	hexCfg, err := parseHexagoConfig(content)
+	if err != nil {
+		return err
+	}
+
+	hexCfg.Generated.Files = slices.DeleteFunc(hexCfg.Generated.Files, func(f HexagoGeneratedFile) bool {
+		return slices.Contains(unrecorded, f.Path)
+	})
+	for _, entry := range manifest {
+		i := slices.IndexFunc(hexCfg.Generated.Files, func(f HexagoGeneratedFile) bool { return f.Path == entry.Path })
+		if i < 0 {
+			hexCfg.Generated.Files = append(hexCfg.Generated.Files, entry)
+			continue
+		}
+		hexCfg.Generated.Files[i] = entry
+	}
+
+	if content, err = MarshalHexagoConfig(hexCfg); err != nil {
+		return err
+	}
+	return c.writeFile(HexagoConfigFile, content)
+}
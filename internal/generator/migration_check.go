@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// migrationNamePattern splits the files of SQL and Go migrations into
+// version, name and kind
+var migrationNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up\.sql|down\.sql|go)$`)
+
+// migrationFiles is what the migrations directory holds for one version
+type migrationFiles struct {
+	names []string // distinct migration names using the version
+	up    bool
+	down  bool
+	goMig bool
+}
+
+// Check inspects the migrations directory for duplicate versions and SQL
+// migrations missing their up or down file. When baseFiles lists the
+// migration files of a base revision (the target branch in CI), migrations
+// added since then must sort after the newest one of the base: golang-migrate
+// never applies a version below the current one of a database.
+func (g *MigrationGenerator) Check(baseFiles []string) (*ValidationResult, error) {
+	result := &ValidationResult{
+		Successes:  make([]ValidationCheck, 0),
+		Violations: make([]Violation, 0),
+	}
+
+	entries, err := g.config.readDirNames("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	versions := make(map[uint64]*migrationFiles)
+	for _, entry := range entries {
+		if strings.HasSuffix(entry, "_test.go") {
+			continue
+		}
+		matches := migrationNamePattern.FindStringSubmatch(entry)
+		if matches == nil {
+			if strings.HasSuffix(entry, ".sql") {
+				result.report("migration-names", SeverityWarning, "migrations/"+entry, "Ignored: expected <version>_<name>.up.sql or .down.sql")
+			}
+			continue
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			result.report("migration-names", SeverityError, "migrations/"+entry, fmt.Sprintf("Invalid version %s", matches[1]))
+			continue
+		}
+
+		files, ok := versions[version]
+		if !ok {
+			files = &migrationFiles{}
+			versions[version] = files
+		}
+		if !slices.Contains(files.names, matches[2]) {
+			files.names = append(files.names, matches[2])
+		}
+		switch matches[3] {
+		case "up.sql":
+			files.up = true
+		case "down.sql":
+			files.down = true
+		case "go":
+			files.goMig = true
+		}
+	}
+
+	if len(versions) == 0 {
+		result.report("migration-files", SeverityWarning, "migrations", "No migrations found")
+		return result, nil
+	}
+
+	sorted := make([]uint64, 0, len(versions))
+	for version := range versions {
+		sorted = append(sorted, version)
+	}
+	slices.Sort(sorted)
+
+	duplicates, incomplete := 0, 0
+	for _, version := range sorted {
+		files := versions[version]
+		if len(files.names) > 1 || (files.goMig && (files.up || files.down)) {
+			duplicates++
+			result.report("migration-versions", SeverityError, "", fmt.Sprintf("Duplicate migration version %06d: %s", version, strings.Join(files.names, ", ")))
+			continue
+		}
+		if files.goMig {
+			continue
+		}
+		if !files.up {
+			incomplete++
+			result.report("migration-files", SeverityError, "", fmt.Sprintf("Migration %06d_%s has no .up.sql file", version, files.names[0]))
+		}
+		if !files.down {
+			incomplete++
+			result.report("migration-files", SeverityError, "", fmt.Sprintf("Migration %06d_%s has no .down.sql file", version, files.names[0]))
+		}
+	}
+	if duplicates == 0 {
+		result.pass("migration-versions", fmt.Sprintf("%d migration version(s) are unique", len(sorted)))
+	}
+	if incomplete == 0 {
+		result.pass("migration-files", "SQL migrations have up and down files")
+	}
+
+	if baseFiles != nil {
+		checkMigrationOrder(result, versions, sorted, baseFiles)
+	}
+
+	return result, nil
+}
+
+// checkMigrationOrder reports the migrations missing from baseFiles that sort
+// before the newest migration of the base
+func checkMigrationOrder(result *ValidationResult, versions map[uint64]*migrationFiles, sorted []uint64, baseFiles []string) {
+	base := make(map[uint64]bool)
+	var newest uint64
+	for _, file := range baseFiles {
+		matches := migrationNamePattern.FindStringSubmatch(file)
+		if matches == nil {
+			continue
+		}
+		if version, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
+			base[version] = true
+			newest = max(newest, version)
+		}
+	}
+
+	added, outOfOrder := 0, 0
+	for _, version := range sorted {
+		if base[version] {
+			continue
+		}
+		added++
+		if version < newest {
+			outOfOrder++
+			result.report("migration-order", SeverityError, "", fmt.Sprintf("Migration %06d_%s is older than %06d, the newest migration of the base; renumber it", version, versions[version].names[0], newest))
+		}
+	}
+	if outOfOrder == 0 {
+		result.pass("migration-order", fmt.Sprintf("%d new migration(s) sort after the base", added))
+	}
+}
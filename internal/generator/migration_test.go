@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestMigrationGeneratorGenerateGo(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("shop", "example.com/shop")
+	config.FS = mem
+	gen := NewMigrationGenerator(config)
+
+	if _, err := gen.Generate("create_users_table"); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	number, err := gen.GenerateGo("backfill_user_slugs")
+	if err != nil {
+		t.Fatalf("GenerateGo() error = %v", err)
+	}
+	if number != 2 {
+		t.Errorf("GenerateGo() = %d, want 2", number)
+	}
+	if number, _ := gen.Generate("add_email_index"); number != 3 {
+		t.Errorf("Generate() after a Go migration = %d, want 3", number)
+	}
+
+	files := map[string][]string{
+		"migrations/000002_backfill_user_slugs.go": {
+			"package migrations",
+			"Version: 2,",
+			`Name:    "backfill_user_slugs",`,
+			"Up: func(ctx context.Context, db *sql.DB, tx *sql.Tx) error {",
+		},
+		"migrations/migrations.go": {"func Register(m *Migration)", "func Versions() []uint"},
+		"internal/infrastructure/database/go_migrations.go": {
+			`"example.com/shop/migrations"`,
+			"func newGoSource(src source.Driver)",
+			"func (d *goDatabase) Run(migration io.Reader) error",
+		},
+		"internal/infrastructure/database/migrator.go": {
+			"newGoSource(files)",
+			"&goDatabase{Driver: driver, db: db}",
+		},
+	}
+	for path, contents := range files {
+		content, err := mem.ReadFile(path)
+		if err != nil {
+			t.Errorf("expected %s to be generated", path)
+			continue
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), path, content, 0); err != nil {
+			t.Errorf("%s is not valid Go: %v", path, err)
+		}
+		for _, s := range contents {
+			if !strings.Contains(string(content), s) {
+				t.Errorf("%s does not contain %q\n%s", path, s, content)
+			}
+		}
+	}
+}
+
+func TestMigrationGeneratorTimestampVersioning(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("shop", "example.com/shop")
+	config.FS = mem
+	config.MigrationVersioning = "timestamp"
+	gen := NewMigrationGenerator(config)
+	gen.now = func() time.Time { return time.Date(2026, 10, 16, 9, 30, 12, 0, time.UTC) }
+
+	if err := mem.MkdirAll("migrations", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile("migrations/000007_create_users_table.up.sql", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	number, err := gen.Generate("add_email_index")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if number != 20261016093012 {
+		t.Errorf("Generate() = %d, want 20261016093012", number)
+	}
+	if _, err := mem.ReadFile("migrations/20261016093012_add_email_index.up.sql"); err != nil {
+		t.Error("expected migrations/20261016093012_add_email_index.up.sql to be generated")
+	}
+
+	// Same second: the version still moves forward
+	if number, _ := gen.GenerateGo("backfill_emails"); number != 20261016093013 {
+		t.Errorf("GenerateGo() = %d, want 20261016093013", number)
+	}
+}
+
+func TestMigrationGeneratorCheck(t *testing.T) {
+	files := []string{
+		"000001_create_users_table.up.sql",
+		"000001_create_users_table.down.sql",
+		"000002_add_phone.up.sql",
+		"000002_add_phone.down.sql",
+		"000002_add_status.up.sql",
+		"000002_add_status.down.sql",
+		"000003_add_index.up.sql",
+		"000004_backfill_slugs.go",
+		"migrations.go",
+		"notes.sql",
+	}
+
+	tests := []struct {
+		name      string
+		files     []string
+		baseFiles []string
+		errors    []string
+		warnings  int
+	}{
+		{
+			name:  "valid",
+			files: []string{files[0], files[1], files[7], files[8]},
+		},
+		{
+			name:  "problems",
+			files: files,
+			errors: []string{
+				"migration-versions: Duplicate migration version 000002: add_phone, add_status",
+				"migration-files: Migration 000003_add_index has no .down.sql file",
+			},
+			warnings: 1,
+		},
+		{
+			name:      "out of order",
+			files:     []string{files[0], files[1], files[2], files[3], files[7]},
+			baseFiles: []string{files[0], files[1], "000003_add_status.up.sql", "000003_add_status.down.sql"},
+			errors:    []string{"migration-order: Migration 000002_add_phone is older than 000003, the newest migration of the base; renumber it"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := fsys.NewMem()
+			config := NewProjectConfig("shop", "example.com/shop")
+			config.FS = mem
+			if err := mem.MkdirAll("migrations", 0o755); err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range tt.files {
+				if err := mem.WriteFile("migrations/"+f, nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := NewMigrationGenerator(config).Check(tt.baseFiles)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got := violationStrings(result.Errors()); !slices.Equal(got, tt.errors) {
+				t.Errorf("Check() errors = %q, want %q", got, tt.errors)
+			}
+			if got := result.Warnings(); len(got) != tt.warnings {
+				t.Errorf("Check() warnings = %q, want %d", violationStrings(got), tt.warnings)
+			}
+		})
+	}
+}
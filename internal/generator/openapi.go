@@ -0,0 +1,792 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const openAPIHeader = `# Generated by ` + "`hexago openapi generate`" + ` from the HTTP handler packages.
+# Regenerate after changing routes or DTOs; manual edits are overwritten.
+`
+
+// OpenAPIOptions configures OpenAPI spec generation
+type OpenAPIOptions struct {
+	Output    string // spec path, relative to the project root
+	Title     string
+	Version   string
+	BasePath  string // prefix the handlers are mounted on, e.g. /api/v1
+	SwaggerUI bool   // also generate the Swagger UI handler package
+}
+
+// OpenAPIGenerator builds an OpenAPI 3 spec from the HTTP handler packages,
+// and scaffolds the project components described by an existing spec
+type OpenAPIGenerator struct {
+	config *ProjectConfig
+}
+
+// NewOpenAPIGenerator creates a new OpenAPI generator
+func NewOpenAPIGenerator(config *ProjectConfig) *OpenAPIGenerator {
+	return &OpenAPIGenerator{
+		config: config,
+	}
+}
+
+// httpRoute is a route registered by a handler package
+type httpRoute struct {
+	Method  string // lower case, as used in OpenAPI path items
+	Path    string
+	Handler string // handler method name
+}
+
+// handlerPackage is a parsed HTTP handler sub-package
+type handlerPackage struct {
+	name     string
+	files    []*ast.File
+	structs  map[string]*ast.StructType
+	todo     map[string]bool // structs still containing a TODO comment
+	funcs    map[string]*ast.FuncDecl
+	methods  map[string]*ast.FuncDecl
+	entity   string
+	routes   []httpRoute
+	receiver string
+}
+
+// Generate writes the OpenAPI spec and returns the number of operations.
+// structs (optional) are the domain structs found by the analyzer; their fields
+// complete DTOs that are still scaffolds.
+func (g *OpenAPIGenerator) Generate(opts OpenAPIOptions, structs []analyzer.DomainStruct) (int, error) {
+	var operations int
+	err := g.config.transact(func() error {
+		var err error
+		operations, err = g.generate(opts, structs)
+		return err
+	})
+	return operations, err
+}
+
+// generate does the work of Generate inside its transaction
+func (g *OpenAPIGenerator) generate(opts OpenAPIOptions, structs []analyzer.DomainStruct) (int, error) {
+	httpDir := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "http")
+	docsDir := filepath.Join(httpDir, "docs")
+
+	if opts.SwaggerUI && !g.config.IsHTTPServer() {
+		return 0, fmt.Errorf("swagger UI requires an http-server project (project type is %s)", g.config.ProjectType)
+	}
+
+	pkgs, err := g.loadHandlerPackages(httpDir)
+	if err != nil {
+		return 0, err
+	}
+
+	doc, operations := g.buildDoc(opts, pkgs, structs)
+	if operations == 0 {
+		fmt.Printf("⚠️  Warning: no routes found in %s/*\n", httpDir)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(openAPIHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return 0, fmt.Errorf("encoding OpenAPI spec: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return 0, fmt.Errorf("encoding OpenAPI spec: %w", err)
+	}
+
+	if err := g.config.createDir(filepath.Dir(opts.Output)); err != nil {
+		return 0, err
+	}
+	fmt.Printf("📝 Writing OpenAPI spec: %s\n", opts.Output)
+	if err := g.config.writeFile(opts.Output, buf.Bytes()); err != nil {
+		return 0, err
+	}
+
+	// Keep the copy served by Swagger UI in sync once the docs package exists
+	if !opts.SwaggerUI && !g.config.fileExists(filepath.Join(docsDir, "docs.go")) {
+		return operations, nil
+	}
+	return operations, g.generateSwaggerUI(docsDir, buf.Bytes())
+}
+
+// generateSwaggerUI writes the docs handler package serving Swagger UI and the spec
+func (g *OpenAPIGenerator) generateSwaggerUI(docsDir string, spec []byte) error {
+	if err := g.config.createDir(docsDir); err != nil {
+		return err
+	}
+
+	specFile := filepath.Join(docsDir, "openapi.yaml")
+	fmt.Printf("📝 Writing Swagger UI spec: %s\n", specFile)
+	if err := g.config.writeFile(specFile, spec); err != nil {
+		return err
+	}
+
+	framework := g.config.Framework
+	if framework == "" {
+		framework = "stdlib"
+	}
+
+	files := []struct{ source, target string }{
+		{"adapter/primary/http/docs_page.go.tmpl", filepath.Join(docsDir, "page.go")},
+		{fmt.Sprintf("adapter/primary/http/%s/http_docs.go.tmpl", framework), filepath.Join(docsDir, "docs.go")},
+	}
+	for _, f := range files {
+		if g.config.fileExists(f.target) {
+			continue
+		}
+
+		fmt.Printf("📝 Creating Swagger UI handler: %s\n", f.target)
+		content, err := g.config.templateLoader.Render(f.source, g.config)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", f.source, err)
+		}
+		if err := g.config.writeGenerated(f.target, f.source, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SwaggerUIRouterField returns the docs.Config field wiring the framework router,
+// as used by the ping handler in the generated http adapter
+func SwaggerUIRouterField(framework string) string {
+	switch framework {
+	case "echo":
+		return "Echo: srv.Echo"
+	case "fiber":
+		return "App: srv.App"
+	case "chi", "gin":
+		return "Router: srv.Router"
+	default:
+		return "Mux: srv.Mux"
+	}
+}
+
+// loadHandlerPackages parses every sub-package of httpDir that registers routes
+func (g *OpenAPIGenerator) loadHandlerPackages(httpDir string) ([]*handlerPackage, error) {
+	entries, err := g.config.readDirNames(httpDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading HTTP adapter dir %s: %w", httpDir, err)
+	}
+	sort.Strings(entries)
+
+	var pkgs []*handlerPackage
+	for _, name := range entries {
+		dir := filepath.Join(httpDir, name)
+		if name == "docs" || !g.config.isDir(dir) {
+			continue
+		}
+
+		pkg, err := g.parseHandlerPackage(dir, name)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: skipping %s: %v\n", dir, err)
+			continue
+		}
+		if len(pkg.routes) > 0 {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+
+	return pkgs, nil
+}
+
+// parseHandlerPackage parses the Go files of a handler package and its routes
+func (g *OpenAPIGenerator) parseHandlerPackage(dir, name string) (*handlerPackage, error) {
+	files, err := g.config.readDirNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &handlerPackage{
+		name:    name,
+		structs: map[string]*ast.StructType{},
+		todo:    map[string]bool{},
+		funcs:   map[string]*ast.FuncDecl{},
+		methods: map[string]*ast.FuncDecl{},
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		path := filepath.Join(dir, file)
+		content, err := g.config.readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, path, content, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		pkg.files = append(pkg.files, f)
+		pkg.collect(f)
+	}
+
+	if configure := pkg.methods["Configure"]; configure != nil {
+		pkg.routes = extractRoutes(configure.Body, pkg.receiver)
+	}
+
+	return pkg, nil
+}
+
+// collect indexes the declarations of a parsed file
+func (p *handlerPackage) collect(f *ast.File) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				p.funcs[d.Name.Name] = d
+				continue
+			}
+			p.methods[d.Name.Name] = d
+			if d.Name.Name == "Configure" && len(d.Recv.List) > 0 && len(d.Recv.List[0].Names) > 0 {
+				p.receiver = d.Recv.List[0].Names[0].Name
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				p.structs[ts.Name.Name] = st
+				p.todo[ts.Name.Name] = hasTODO(f, st)
+
+				// The served entity: manage *xSvc.<Entity>Service
+				if ts.Name.Name == "handler" {
+					p.entity = managedEntity(st)
+				}
+			}
+		}
+	}
+}
+
+// hasTODO reports whether a struct body still contains a TODO comment
+func hasTODO(f *ast.File, st *ast.StructType) bool {
+	for _, group := range f.Comments {
+		if group.Pos() > st.Fields.Opening && group.End() < st.Fields.Closing && strings.Contains(group.Text(), "TODO") {
+			return true
+		}
+	}
+	return false
+}
+
+// managedEntity returns <Entity> from a `manage *pkg.<Entity>Service` field
+func managedEntity(st *ast.StructType) string {
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 || field.Names[0].Name != "manage" {
+			continue
+		}
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		if sel, ok := expr.(*ast.SelectorExpr); ok {
+			return strings.TrimSuffix(sel.Sel.Name, "Service")
+		}
+	}
+	return ""
+}
+
+// routeMethods maps router method names to HTTP methods
+var routeMethods = map[string]string{
+	"Get": "get", "GET": "get",
+	"Post": "post", "POST": "post",
+	"Put": "put", "PUT": "put",
+	"Patch": "patch", "PATCH": "patch",
+	"Delete": "delete", "DELETE": "delete",
+}
+
+// extractRoutes finds the routes registered on recv's handler methods:
+// chi Route blocks, echo/gin/fiber Group variables and stdlib "METHOD /path" patterns
+func extractRoutes(body *ast.BlockStmt, recv string) []httpRoute {
+	var routes []httpRoute
+	groups := map[string]string{}
+
+	var walk func(node ast.Node, prefix string)
+	walk = func(node ast.Node, prefix string) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.AssignStmt:
+				// v1 := e.Group("/orders")
+				if len(x.Lhs) != 1 || len(x.Rhs) != 1 {
+					return true
+				}
+				ident, ok := x.Lhs[0].(*ast.Ident)
+				call, isCall := x.Rhs[0].(*ast.CallExpr)
+				if !ok || !isCall {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
+					return true
+				}
+				if p, ok := stringLit(call.Args[0]); ok {
+					groups[ident.Name] = joinRoute(groupPrefix(sel.X, groups, prefix), p)
+				}
+
+			case *ast.CallExpr:
+				sel, ok := x.Fun.(*ast.SelectorExpr)
+				if !ok || len(x.Args) < 2 {
+					return true
+				}
+				base := groupPrefix(sel.X, groups, prefix)
+				path, ok := stringLit(x.Args[0])
+				if !ok {
+					return true
+				}
+
+				switch name := sel.Sel.Name; {
+				case name == "Route":
+					// chi: r.Route("/orders", func(r chi.Router) { ... })
+					if fn, ok := x.Args[1].(*ast.FuncLit); ok {
+						walk(fn.Body, joinRoute(base, path))
+						return false
+					}
+				case name == "HandleFunc":
+					// stdlib: mux.HandleFunc("GET /orders/{id}", h.GetByID)
+					method, pattern, found := strings.Cut(path, " ")
+					handler := handlerName(x.Args[len(x.Args)-1], recv)
+					if found && handler != "" {
+						routes = append(routes, httpRoute{Method: strings.ToLower(method), Path: joinRoute(base, strings.TrimSpace(pattern)), Handler: handler})
+					}
+				case routeMethods[name] != "":
+					if handler := handlerName(x.Args[len(x.Args)-1], recv); handler != "" {
+						routes = append(routes, httpRoute{Method: routeMethods[name], Path: joinRoute(base, path), Handler: handler})
+					}
+				}
+			}
+			return true
+		})
+	}
+	walk(body, "")
+
+	return routes
+}
+
+// groupPrefix returns the prefix of a group variable, or the current prefix
+func groupPrefix(expr ast.Expr, groups map[string]string, prefix string) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if p, ok := groups[ident.Name]; ok {
+			return p
+		}
+	}
+	return prefix
+}
+
+// handlerName returns X for a recv.X handler expression
+func handlerName(expr ast.Expr, recv string) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != recv {
+		return ""
+	}
+	return sel.Sel.Name
+}
+
+// stringLit returns the value of a string literal
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+var routeParamPattern = regexp.MustCompile(`:(\w+)`)
+
+// joinRoute joins route segments into an OpenAPI path (":id" becomes "{id}")
+func joinRoute(prefix, path string) string {
+	joined := strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+	joined = routeParamPattern.ReplaceAllString(joined, "{$1}")
+	if len(joined) > 1 {
+		joined = strings.TrimSuffix(joined, "/")
+	}
+	return joined
+}
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// openAPIBuilder accumulates the spec of all handler packages
+type openAPIBuilder struct {
+	doc      *openAPIDoc
+	structs  []analyzer.DomainStruct
+	owners   map[string]string // component name → package that registered it
+	statuses map[string]int
+}
+
+// buildDoc builds the spec and returns it with its number of operations
+func (g *OpenAPIGenerator) buildDoc(opts OpenAPIOptions, pkgs []*handlerPackage, structs []analyzer.DomainStruct) (*openAPIDoc, int) {
+	b := &openAPIBuilder{
+		doc: &openAPIDoc{
+			OpenAPI:    "3.0.3",
+			Info:       openAPIInfo{Title: opts.Title, Version: opts.Version},
+			Paths:      map[string]map[string]*openAPIOperation{},
+			Components: openAPIComponents{Schemas: map[string]*openAPISchema{}},
+		},
+		structs:  structs,
+		owners:   map[string]string{},
+		statuses: httpStatusNames(),
+	}
+	if opts.BasePath != "" {
+		b.doc.Servers = []openAPIServer{{URL: opts.BasePath}}
+	}
+
+	operations := 0
+	for _, pkg := range pkgs {
+		tag := openAPITag{Name: pkg.name}
+		if pkg.entity != "" {
+			tag.Description = fmt.Sprintf("%s operations", pkg.entity)
+		}
+		b.doc.Tags = append(b.doc.Tags, tag)
+
+		for _, route := range pkg.routes {
+			item, ok := b.doc.Paths[route.Path]
+			if !ok {
+				item = map[string]*openAPIOperation{}
+				b.doc.Paths[route.Path] = item
+			}
+			item[route.Method] = b.operation(pkg, route)
+			operations++
+		}
+	}
+
+	return b.doc, operations
+}
+
+// operation builds the OpenAPI operation of a route
+func (b *openAPIBuilder) operation(pkg *handlerPackage, route httpRoute) *openAPIOperation {
+	op := &openAPIOperation{
+		Tags:        []string{pkg.name},
+		OperationID: pkg.name + route.Handler,
+		Responses:   map[string]openAPIResponse{},
+	}
+
+	for _, m := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &openAPISchema{Type: "string"},
+		})
+	}
+
+	decl := pkg.methods[route.Handler]
+	if decl == nil {
+		op.Responses["200"] = openAPIResponse{Description: http.StatusText(http.StatusOK)}
+		return op
+	}
+	if decl.Doc != nil {
+		op.Summary = strings.TrimSpace(strings.SplitN(decl.Doc.Text(), "\n", 2)[0])
+	}
+
+	request, response, array, codes := b.inspectHandler(pkg, decl)
+
+	if request != "" {
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{"application/json": {Schema: b.component(pkg, request)}},
+		}
+	}
+
+	success := http.StatusOK
+	for _, code := range codes {
+		if code >= 200 && code < 300 {
+			success = code
+			break
+		}
+	}
+	resp := openAPIResponse{Description: http.StatusText(success)}
+	if response != "" && success != http.StatusNoContent {
+		schema := b.component(pkg, response)
+		if array {
+			schema = &openAPISchema{Type: "array", Items: schema}
+		}
+		resp.Content = map[string]openAPIMediaType{"application/json": {Schema: schema}}
+	}
+	op.Responses[strconv.Itoa(success)] = resp
+
+	// Error responses: the statuses used in the handler, plus the usual ones
+	if request != "" {
+		codes = append(codes, http.StatusBadRequest)
+	}
+	if len(op.Parameters) > 0 {
+		codes = append(codes, http.StatusNotFound)
+	}
+	codes = append(codes, http.StatusInternalServerError)
+	for _, code := range codes {
+		if code >= 400 {
+			op.Responses[strconv.Itoa(code)] = openAPIResponse{Description: http.StatusText(code)}
+		}
+	}
+
+	return op
+}
+
+// inspectHandler finds the request DTO, the response DTO (and whether it is a list)
+// and the HTTP statuses used by a handler method
+func (b *openAPIBuilder) inspectHandler(pkg *handlerPackage, decl *ast.FuncDecl) (request, response string, array bool, codes []int) {
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.ValueSpec:
+			// var req createOrderRequest
+			if ident, ok := x.Type.(*ast.Ident); ok && pkg.structs[ident.Name] != nil && request == "" {
+				request = ident.Name
+			}
+		case *ast.CallExpr:
+			// make([]orderResponse, 0, len(items))
+			if ident, ok := x.Fun.(*ast.Ident); ok && ident.Name == "make" && len(x.Args) > 0 {
+				if arr, ok := x.Args[0].(*ast.ArrayType); ok {
+					if elem, ok := arr.Elt.(*ast.Ident); ok && pkg.structs[elem.Name] != nil {
+						response, array = elem.Name, true
+					}
+				}
+			}
+			// toOrderResponse(item)
+			if ident, ok := x.Fun.(*ast.Ident); ok && response == "" {
+				if fn := pkg.funcs[ident.Name]; fn != nil && fn.Type.Results != nil && len(fn.Type.Results.List) == 1 {
+					if res, ok := fn.Type.Results.List[0].Type.(*ast.Ident); ok && pkg.structs[res.Name] != nil {
+						response = res.Name
+					}
+				}
+			}
+		case *ast.SelectorExpr:
+			// http.StatusCreated, fiber.StatusCreated
+			if code, ok := b.statuses[x.Sel.Name]; ok {
+				codes = append(codes, code)
+			}
+		}
+		return true
+	})
+	return request, response, array, codes
+}
+
+// httpStatusNames maps net/http status constant names to their codes
+func httpStatusNames() map[string]int {
+	names := map[string]int{}
+	for code := 100; code < 600; code++ {
+		text := http.StatusText(code)
+		if text == "" {
+			continue
+		}
+		name := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, text)
+		names["Status"+name] = code
+	}
+	return names
+}
+
+// component registers the schema of a package struct and returns a reference to it
+func (b *openAPIBuilder) component(pkg *handlerPackage, name string) *openAPISchema {
+	compName := utils.ToTitleCase(name)
+	if owner, ok := b.owners[compName]; ok && owner != pkg.name {
+		compName = utils.ToTitleCase(pkg.name) + compName
+	}
+	if _, ok := b.doc.Components.Schemas[compName]; ok {
+		return openAPIRef(compName)
+	}
+
+	schema := &openAPISchema{Type: "object"}
+	b.owners[compName] = pkg.name
+	b.doc.Components.Schemas[compName] = schema // registered first: structs may be recursive
+
+	st := pkg.structs[name]
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+		jsonName, omitEmpty, skip := jsonTag(field)
+		for _, ident := range field.Names {
+			if !ident.IsExported() || skip {
+				continue
+			}
+			propName := jsonName
+			if propName == "" {
+				propName = ident.Name
+			}
+			schema.Properties = append(schema.Properties, openAPIProperty{Name: propName, Schema: b.exprSchema(pkg, field.Type)})
+			if _, isPtr := field.Type.(*ast.StarExpr); !omitEmpty && !isPtr {
+				schema.Required = append(schema.Required, propName)
+			}
+		}
+	}
+
+	// DTOs that are still scaffolds are completed from the domain entity
+	if pkg.todo[name] {
+		b.completeFromEntity(pkg, name, schema)
+	}
+
+	return openAPIRef(compName)
+}
+
+// completeFromEntity adds the entity fields missing from a scaffolded DTO.
+// Request DTOs leave out the identity and timestamps set by the service.
+func (b *openAPIBuilder) completeFromEntity(pkg *handlerPackage, name string, schema *openAPISchema) {
+	entity := b.findEntity(pkg.entity)
+	if entity == nil {
+		return
+	}
+
+	isRequest := strings.HasSuffix(name, "Request")
+	existing := map[string]bool{}
+	for _, prop := range schema.Properties {
+		existing[prop.Name] = true
+	}
+
+	for _, f := range entity.Fields {
+		if !isExported(f.Name) {
+			continue
+		}
+		propName := snakeCaseName(f.Name)
+		if existing[propName] {
+			continue
+		}
+		if isRequest && (propName == "id" || propName == "created_at" || propName == "updated_at") {
+			continue
+		}
+		schema.Properties = append(schema.Properties, openAPIProperty{Name: propName, Schema: goTypeSchema(f.Type)})
+	}
+}
+
+// findEntity looks up the domain struct of an entity, preferring the domain layer
+func (b *openAPIBuilder) findEntity(name string) *analyzer.DomainStruct {
+	if name == "" {
+		return nil
+	}
+	var found *analyzer.DomainStruct
+	for i := range b.structs {
+		s := &b.structs[i]
+		if s.Name != name {
+			continue
+		}
+		if strings.Contains(s.ImportPath, "/internal/core/domain") {
+			return s
+		}
+		if found == nil {
+			found = s
+		}
+	}
+	return found
+}
+
+// exprSchema returns the schema of a Go type expression in a handler package
+func (b *openAPIBuilder) exprSchema(pkg *handlerPackage, expr ast.Expr) *openAPISchema {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if pkg.structs[t.Name] != nil {
+			return b.component(pkg, t.Name)
+		}
+		return goTypeSchema(t.Name)
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			return goTypeSchema(x.Name + "." + t.Sel.Name)
+		}
+	case *ast.InterfaceType:
+		return &openAPISchema{}
+	case *ast.StarExpr:
+		schema := b.exprSchema(pkg, t.X)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: b.exprSchema(pkg, t.Elt)}
+	case *ast.MapType:
+		return &openAPISchema{Type: "object", AdditionalProperties: b.exprSchema(pkg, t.Value)}
+	}
+	return &openAPISchema{Type: "object"}
+}
+
+// goTypeSchema returns the schema of a Go type name, as written in source
+// (time.Time) or as reported by the analyzer (Time)
+func goTypeSchema(goType string) *openAPISchema {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		schema := goTypeSchema(goType[1:])
+		schema.Nullable = true
+		return schema
+	case goType == "[]byte" || goType == "[]uint8":
+		return &openAPISchema{Type: "string", Format: "byte"}
+	case strings.HasPrefix(goType, "[]"):
+		return &openAPISchema{Type: "array", Items: goTypeSchema(goType[2:])}
+	case strings.HasPrefix(goType, "map["):
+		if end := strings.Index(goType, "]"); end > 0 {
+			return &openAPISchema{Type: "object", AdditionalProperties: goTypeSchema(goType[end+1:])}
+		}
+	}
+
+	switch goType {
+	case "string":
+		return &openAPISchema{Type: "string"}
+	case "bool":
+		return &openAPISchema{Type: "boolean"}
+	case "int", "int64", "uint", "uint64":
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32", "byte", "rune":
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case "float64":
+		return &openAPISchema{Type: "number", Format: "double"}
+	case "float32":
+		return &openAPISchema{Type: "number", Format: "float"}
+	case "Time", "time.Time":
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case "Duration", "time.Duration":
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case "UUID", "uuid.UUID":
+		return &openAPISchema{Type: "string", Format: "uuid"}
+	case "any", "interface{}", "json.RawMessage":
+		return &openAPISchema{}
+	}
+	return &openAPISchema{Type: "object"}
+}
+
+// jsonTag returns the JSON name of a struct field and its omitempty/skip options
+func jsonTag(field *ast.Field) (name string, omitEmpty, skip bool) {
+	if field.Tag == nil {
+		return "", false, false
+	}
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false, false
+	}
+	tag, ok := reflect.StructTag(raw).Lookup("json")
+	if !ok {
+		return "", false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, strings.Contains(opts, "omitempty"), false
+}
@@ -0,0 +1,610 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/padiazg/hexago/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIImportResult lists the components scaffolded from an OpenAPI spec
+type OpenAPIImportResult struct {
+	Entities []string
+	Services []string
+	Handlers []string
+	Skipped  []string // components that already existed
+	Unmapped []string // operations the handler templates do not cover, e.g. "DELETE /orders/{id}"
+}
+
+// openAPISource is an OpenAPI 3 document read for import. Path items are kept
+// as nodes: besides operations they may hold parameters and descriptions.
+type openAPISource struct {
+	OpenAPI    string                          `yaml:"openapi"`
+	Swagger    string                          `yaml:"swagger"`
+	Tags       []openAPITag                    `yaml:"tags"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components openAPIComponents               `yaml:"components"`
+}
+
+// openAPIMethods are the path item keys imported as operations, in output order
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// specOperation is an operation of the imported spec
+type specOperation struct {
+	Method string
+	Path   string
+	Rest   []string // path segments after the group segment
+	Op     *openAPIOperation
+}
+
+func (o specOperation) String() string {
+	return strings.ToUpper(o.Method) + " " + o.Path
+}
+
+// pathGroup is the set of operations under the same first path segment,
+// e.g. /orders and /orders/{id}. Each group becomes a handler package.
+type pathGroup struct {
+	name       string
+	entity     string
+	operations []specOperation
+}
+
+// find returns the group operation with method whose path has n segments after the group
+func (p *pathGroup) find(method string, n int) *openAPIOperation {
+	for _, o := range p.operations {
+		if o.Method == method && len(o.Rest) == n && (n == 0 || isPathParam(o.Rest[0])) {
+			return o.Op
+		}
+	}
+	return nil
+}
+
+// openAPIImporter resolves the schemas of an imported spec
+type openAPIImporter struct {
+	schemas map[string]*openAPISchema
+}
+
+// Import scaffolds the domain entities, services and HTTP handler packages
+// described by an OpenAPI 3 spec:
+//   - one entity per component schema, except request bodies and error payloads
+//   - one service per tag, bound to the entity of the tagged path group
+//   - one handler package per path group (/orders, /orders/{id}) with DTOs from the
+//     request and response schemas
+//
+// Components that already exist are skipped, so an import can be re-run after
+// the spec grows.
+func (g *OpenAPIGenerator) Import(spec []byte) (*OpenAPIImportResult, error) {
+	var result *OpenAPIImportResult
+	err := g.config.transact(func() error {
+		var err error
+		result, err = g.importSpec(spec)
+		return err
+	})
+	return result, err
+}
+
+// importSpec does the work of Import inside its transaction
+func (g *OpenAPIGenerator) importSpec(spec []byte) (*OpenAPIImportResult, error) {
+	var src openAPISource
+	if err := yaml.Unmarshal(spec, &src); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI spec: %w", err)
+	}
+	if !strings.HasPrefix(src.OpenAPI, "3.") {
+		if src.Swagger != "" {
+			return nil, fmt.Errorf("swagger %s specs are not supported; convert the spec to OpenAPI 3 first", src.Swagger)
+		}
+		return nil, fmt.Errorf("not an OpenAPI 3 spec (openapi: %q)", src.OpenAPI)
+	}
+
+	imp := &openAPIImporter{schemas: src.Components.Schemas}
+	if imp.schemas == nil {
+		imp.schemas = map[string]*openAPISchema{}
+	}
+
+	result := &OpenAPIImportResult{}
+	groups, err := imp.groupOperations(src.Paths, result)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := imp.entities(groups)
+	if err := g.importEntities(imp, entities, result); err != nil {
+		return nil, err
+	}
+	if err := g.importServices(src.Tags, groups, result); err != nil {
+		return nil, err
+	}
+	if err := g.importHandlers(imp, groups, entities, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// groupOperations decodes the operations of the spec and groups them by their
+// first static path segment (version prefixes such as /api/v1 are ignored)
+func (imp *openAPIImporter) groupOperations(paths map[string]map[string]yaml.Node, result *OpenAPIImportResult) ([]*pathGroup, error) {
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+
+	byName := map[string]*pathGroup{}
+	var groups []*pathGroup
+	for _, path := range keys {
+		item := paths[path]
+		for _, method := range openAPIMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			op := &openAPIOperation{}
+			if err := node.Decode(op); err != nil {
+				return nil, fmt.Errorf("paths.%s.%s: %w", path, method, err)
+			}
+
+			name, rest := splitResourcePath(path)
+			o := specOperation{Method: method, Path: path, Rest: rest, Op: op}
+			if name == "" {
+				result.Unmapped = append(result.Unmapped, o.String())
+				continue
+			}
+
+			group := byName[name]
+			if group == nil {
+				group = &pathGroup{name: name}
+				byName[name] = group
+				groups = append(groups, group)
+			}
+			group.operations = append(group.operations, o)
+		}
+	}
+
+	for _, group := range groups {
+		group.entity = imp.groupEntity(group)
+	}
+
+	return groups, nil
+}
+
+var versionSegmentPattern = regexp.MustCompile(`^v\d+$`)
+
+// splitResourcePath returns the resource segment of a path and the segments after it
+func splitResourcePath(path string) (string, []string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segments {
+		if seg == "" || isPathParam(seg) {
+			return "", nil
+		}
+		if seg == "api" || versionSegmentPattern.MatchString(seg) {
+			continue
+		}
+		return seg, segments[i+1:]
+	}
+	return "", nil
+}
+
+// isPathParam reports whether a path segment is a {parameter}
+func isPathParam(seg string) bool {
+	return strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")
+}
+
+// groupEntity names the entity served by a path group: the schema returned by
+// its item or collection GET, its create response, or the singular group name
+func (imp *openAPIImporter) groupEntity(group *pathGroup) string {
+	candidates := []*openAPIOperation{group.find("get", 1), group.find("get", 0), group.find("post", 0)}
+	for _, op := range candidates {
+		if op == nil {
+			continue
+		}
+		schema := successSchema(op)
+		if schema != nil && schema.Type == "array" {
+			schema = schema.Items
+		}
+		if schema != nil && schema.Ref != "" {
+			return goIdentifier(refName(schema.Ref))
+		}
+	}
+	return goIdentifier(utils.ToSingular(group.name))
+}
+
+// successSchema returns the JSON schema of the first 2xx response of an operation
+func successSchema(op *openAPIOperation) *openAPISchema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if media, ok := op.Responses[code].Content["application/json"]; ok && media.Schema != nil {
+			return media.Schema
+		}
+	}
+	return nil
+}
+
+// requestSchema returns the JSON request body schema of an operation
+func requestSchema(op *openAPIOperation) *openAPISchema {
+	if op == nil || op.RequestBody == nil {
+		return nil
+	}
+	return op.RequestBody.Content["application/json"].Schema
+}
+
+// entities returns the entity schemas by Go name. Path group entities come
+// first; schemas used only as request bodies or error payloads are left out.
+// A nil schema means the entity has no schema and gets the default fields.
+func (imp *openAPIImporter) entities(groups []*pathGroup) map[string]*openAPISchema {
+	entities := map[string]*openAPISchema{}
+	for _, group := range groups {
+		entities[group.entity] = nil
+	}
+
+	// How each component schema is referenced by the operations
+	requests, failures, others := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, group := range groups {
+		for _, o := range group.operations {
+			if schema := requestSchema(o.Op); schema != nil && schema.Ref != "" {
+				requests[refName(schema.Ref)] = true
+			}
+			for code, resp := range o.Op.Responses {
+				schema := resp.Content["application/json"].Schema
+				if schema != nil && schema.Type == "array" {
+					schema = schema.Items
+				}
+				if schema == nil || schema.Ref == "" {
+					continue
+				}
+				if strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5") || code == "default" {
+					failures[refName(schema.Ref)] = true
+				} else {
+					others[refName(schema.Ref)] = true
+				}
+			}
+		}
+	}
+
+	for name, schema := range imp.schemas {
+		goName := goIdentifier(name)
+		if _, isGroup := entities[goName]; isGroup {
+			entities[goName] = schema
+			continue
+		}
+		if !isObjectSchema(schema) || (!others[name] && (requests[name] || failures[name])) {
+			continue
+		}
+		entities[goName] = schema
+	}
+
+	return entities
+}
+
+// isObjectSchema reports whether a schema describes an object with properties
+func isObjectSchema(schema *openAPISchema) bool {
+	return schema != nil && (schema.Type == "object" || schema.Type == "") && len(schema.Properties) > 0
+}
+
+// importEntities generates a domain entity per entity schema
+func (g *OpenAPIGenerator) importEntities(imp *openAPIImporter, entities map[string]*openAPISchema, result *OpenAPIImportResult) error {
+	domain := NewDomainGenerator(g.config)
+	for _, name := range sortedKeys(entities) {
+		pkgName := utils.ToPlural(strings.ToLower(name))
+		path := filepath.Join("internal", "core", "domain", pkgName, pkgName+".go")
+		if g.config.fileExists(path) {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+
+		var fields []Field
+		for _, f := range imp.fields(entities[name]) {
+			fields = append(fields, Field{Name: f.Name, Type: f.Type})
+		}
+		if err := domain.generateEntity(name, fields); err != nil {
+			return fmt.Errorf("entity %s: %w", name, err)
+		}
+		result.Entities = append(result.Entities, name)
+	}
+	return nil
+}
+
+// importServices generates a service per tag. A tag is bound to the entity of
+// the first path group using it; path groups without a tag get their own service.
+func (g *OpenAPIGenerator) importServices(tags []openAPITag, groups []*pathGroup, result *OpenAPIImportResult) error {
+	type tagService struct {
+		name        string
+		entity      string
+		description string
+	}
+
+	var services []*tagService
+	byTag := map[string]*tagService{}
+	addTag := func(name, description string) *tagService {
+		if s, ok := byTag[name]; ok {
+			return s
+		}
+		s := &tagService{name: name, description: description}
+		byTag[name] = s
+		services = append(services, s)
+		return s
+	}
+
+	for _, tag := range tags {
+		addTag(tag.Name, tag.Description)
+	}
+	bound := map[string]bool{}
+	for _, group := range groups {
+		for _, o := range group.operations {
+			opTags := o.Op.Tags
+			if len(opTags) == 0 {
+				opTags = []string{group.name}
+			}
+			for _, tag := range opTags {
+				if s := addTag(tag, ""); s.entity == "" && !bound[group.entity] {
+					s.entity = group.entity
+					bound[group.entity] = true
+				}
+			}
+		}
+	}
+	// Every handler package needs the service of its entity
+	for _, group := range groups {
+		if !bound[group.entity] {
+			addTag(group.name, "").entity = group.entity
+			bound[group.entity] = true
+		}
+	}
+
+	gen := NewServiceGenerator(g.config)
+	for _, s := range services {
+		serviceName := s.entity
+		pkgName := utils.ToPlural(strings.ToLower(s.entity))
+		if s.entity == "" {
+			serviceName = goIdentifier(s.name)
+			pkgName = strings.ToLower(serviceName)
+		}
+		if serviceName == "" {
+			continue
+		}
+
+		path := filepath.Join("internal", "core", g.config.CoreLogicDir(), pkgName, pkgName+".go")
+		if g.config.fileExists(path) {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+
+		if err := gen.generate(serviceName, s.entity, s.description, nil); err != nil {
+			return fmt.Errorf("service for tag %s: %w", s.name, err)
+		}
+		result.Services = append(result.Services, serviceName)
+	}
+	return nil
+}
+
+// importHandlers generates an HTTP handler package per path group
+func (g *OpenAPIGenerator) importHandlers(imp *openAPIImporter, groups []*pathGroup, entities map[string]*openAPISchema, result *OpenAPIImportResult) error {
+	framework := g.config.Framework
+	if framework == "" {
+		framework = "chi"
+	}
+
+	switch {
+	case !g.config.IsHTTPServer():
+		fmt.Printf("⚠️  Warning: %s projects have no HTTP adapter; skipping handler packages\n", g.config.ProjectType)
+		return nil
+	case !g.config.templateLoader.Exists(fmt.Sprintf("adapter/primary/http/%s/handler_config.go.tmpl", framework)):
+		fmt.Printf("⚠️  Warning: no handler package templates for %s; skipping handler packages\n", framework)
+		return nil
+	}
+
+	adapters := NewAdapterGenerator(g.config)
+	for _, group := range groups {
+		pkgName := utils.ToPlural(strings.ToLower(group.entity))
+		path := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "http", pkgName, utils.ToSnakeCase(group.entity)+".go")
+		if g.config.fileExists(path) {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+		if pkgName != group.name {
+			fmt.Printf("⚠️  Warning: /%s operations are scaffolded on /%s\n", group.name, pkgName)
+		}
+
+		entityFields := map[string]string{}
+		for _, f := range imp.fields(entities[group.entity]) {
+			entityFields[f.Name] = f.Type
+		}
+
+		update := group.find("put", 1)
+		if update == nil {
+			update = group.find("patch", 1)
+		}
+		dtos := &handlerDTOs{
+			Create:   imp.fields(requestSchema(group.find("post", 0))),
+			Update:   imp.fields(requestSchema(update)),
+			Response: imp.fields(entities[group.entity]),
+		}
+		for i, f := range dtos.Response {
+			dtos.Response[i].Mapped = entityFields[f.Name] == f.Type
+		}
+
+		if err := adapters.generateHTTPHandlerPackage(group.entity+"Handler", group.entity, dtos); err != nil {
+			return fmt.Errorf("handler for /%s: %w", group.name, err)
+		}
+		result.Handlers = append(result.Handlers, pkgName)
+
+		// The templates register List, Create, GetByID and Update
+		for _, o := range group.operations {
+			scaffolded := (len(o.Rest) == 0 && (o.Method == "get" || o.Method == "post")) ||
+				(len(o.Rest) == 1 && isPathParam(o.Rest[0]) && (o.Method == "get" || o.Method == "put"))
+			if !scaffolded {
+				result.Unmapped = append(result.Unmapped, o.String())
+			}
+		}
+	}
+	return nil
+}
+
+// fields returns the DTO fields of an object schema, in declaration order.
+// Properties that are not required are tagged omitempty.
+func (imp *openAPIImporter) fields(schema *openAPISchema) []dtoField {
+	schema = imp.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	fields := make([]dtoField, 0, len(schema.Properties))
+	for _, prop := range schema.Properties {
+		tag := prop.Name
+		if !required[prop.Name] {
+			tag += ",omitempty"
+		}
+		fields = append(fields, dtoField{
+			Name: goIdentifier(prop.Name),
+			Type: imp.goType(prop.Schema),
+			Tag:  tag,
+		})
+	}
+	return fields
+}
+
+// resolve follows a component reference
+func (imp *openAPIImporter) resolve(schema *openAPISchema) *openAPISchema {
+	for i := 0; schema != nil && schema.Ref != "" && i < 8; i++ {
+		schema = imp.schemas[refName(schema.Ref)]
+	}
+	return schema
+}
+
+// goType returns the Go type of a property schema. References to other object
+// schemas are typed any: the entities live in separate packages.
+func (imp *openAPIImporter) goType(schema *openAPISchema) string {
+	if schema == nil {
+		return "any"
+	}
+	if schema.Ref != "" {
+		target := imp.resolve(schema)
+		if target == nil || isObjectSchema(target) {
+			return "any"
+		}
+		return imp.goType(target)
+	}
+
+	var goType string
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			goType = "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		default:
+			goType = "string"
+		}
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			goType = "int32"
+		case "int64":
+			goType = "int64"
+		default:
+			goType = "int"
+		}
+	case "number":
+		if schema.Format == "float" {
+			goType = "float32"
+		} else {
+			goType = "float64"
+		}
+	case "boolean":
+		goType = "bool"
+	case "array":
+		return "[]" + imp.goType(schema.Items)
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "map[string]" + imp.goType(schema.AdditionalProperties)
+		}
+		return "map[string]any"
+	default:
+		return "any"
+	}
+
+	if schema.Nullable {
+		return "*" + goType
+	}
+	return goType
+}
+
+// refName returns the component name of a local schema reference
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// goInitialisms are the name parts written in upper case, as golint expects
+var goInitialisms = map[string]bool{
+	"ID": true, "URL": true, "URI": true, "API": true, "HTTP": true,
+	"UUID": true, "JSON": true, "SKU": true, "IP": true, "SQL": true,
+}
+
+// goIdentifier converts a schema or property name (order_item, orderItem,
+// order-item) to an exported Go identifier (OrderItem), keeping initialisms
+// upper case (user_id → UserID)
+func goIdentifier(s string) string {
+	var parts []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			parts = append(parts, string(word))
+			word = nil
+		}
+	}
+	for i, r := range s {
+		switch {
+		case !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9'):
+			flush()
+		case r >= 'A' && r <= 'Z' && i > 0 && s[i-1] >= 'a' && s[i-1] <= 'z':
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, part := range parts {
+		if upper := strings.ToUpper(part); goInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(utils.ToTitleCase(part))
+	}
+
+	name := b.String()
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "X" + name
+	}
+	return name
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDoc is the subset of an OpenAPI 3 document hexago reads and writes
+type openAPIDoc struct {
+	OpenAPI    string                                  `yaml:"openapi"`
+	Info       openAPIInfo                             `yaml:"info"`
+	Servers    []openAPIServer                         `yaml:"servers,omitempty"`
+	Tags       []openAPITag                            `yaml:"tags,omitempty"`
+	Paths      map[string]map[string]*openAPIOperation `yaml:"paths"`
+	Components openAPIComponents                       `yaml:"components,omitempty"`
+}
+
+type openAPIInfo struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version"`
+}
+
+type openAPIServer struct {
+	URL string `yaml:"url"`
+}
+
+type openAPITag struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+}
+
+type openAPIOperation struct {
+	Tags        []string                   `yaml:"tags,omitempty"`
+	OperationID string                     `yaml:"operationId,omitempty"`
+	Summary     string                     `yaml:"summary,omitempty"`
+	Parameters  []openAPIParameter         `yaml:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `yaml:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required,omitempty"`
+	Schema   *openAPISchema `yaml:"schema,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `yaml:"required,omitempty"`
+	Content  map[string]openAPIMediaType `yaml:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `yaml:"schema,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string                      `yaml:"description"`
+	Content     map[string]openAPIMediaType `yaml:"content,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `yaml:"schemas,omitempty"`
+}
+
+// openAPISchema is a JSON schema; properties keep their declaration order
+type openAPISchema struct {
+	Ref                  string            `yaml:"$ref,omitempty"`
+	Type                 string            `yaml:"type,omitempty"`
+	Format               string            `yaml:"format,omitempty"`
+	Description          string            `yaml:"description,omitempty"`
+	Nullable             bool              `yaml:"nullable,omitempty"`
+	Items                *openAPISchema    `yaml:"items,omitempty"`
+	Properties           openAPIProperties `yaml:"properties,omitempty"`
+	Required             []string          `yaml:"required,omitempty"`
+	AdditionalProperties *openAPISchema    `yaml:"additionalProperties,omitempty"`
+	Enum                 []string          `yaml:"enum,omitempty"`
+}
+
+// openAPIProperty is a named schema property
+type openAPIProperty struct {
+	Name   string
+	Schema *openAPISchema
+}
+
+// openAPIProperties is an ordered properties map
+type openAPIProperties []openAPIProperty
+
+// MarshalYAML writes the properties as a mapping in declaration order
+func (p openAPIProperties) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, prop := range p {
+		value := &yaml.Node{}
+		if err := value.Encode(prop.Schema); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: prop.Name}, value)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML reads a properties mapping keeping the document order
+func (p *openAPIProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		schema := &openAPISchema{}
+		if err := node.Content[i+1].Decode(schema); err != nil {
+			return err
+		}
+		*p = append(*p, openAPIProperty{Name: node.Content[i].Value, Schema: schema})
+	}
+	return nil
+}
+
+// openAPIRef returns a reference to a component schema
+func openAPIRef(name string) *openAPISchema {
+	return &openAPISchema{Ref: "#/components/schemas/" + name}
+}
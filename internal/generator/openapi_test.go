@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestExtractRoutes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []httpRoute
+	}{
+		{
+			name: "chi route block",
+			body: `r.Route("/orders", func(r chi.Router) {
+				r.Get("/", h.List)
+				r.Post("/", h.Create)
+				r.Get("/{id}", h.GetByID)
+			})`,
+			want: []httpRoute{
+				{Method: "get", Path: "/orders", Handler: "List"},
+				{Method: "post", Path: "/orders", Handler: "Create"},
+				{Method: "get", Path: "/orders/{id}", Handler: "GetByID"},
+			},
+		},
+		{
+			name: "echo group",
+			body: `g := e.Group("/orders")
+				g.GET("", h.List)
+				g.DELETE("/:id", h.Delete)`,
+			want: []httpRoute{
+				{Method: "get", Path: "/orders", Handler: "List"},
+				{Method: "delete", Path: "/orders/{id}", Handler: "Delete"},
+			},
+		},
+		{
+			name: "stdlib patterns",
+			body: `mux.HandleFunc("PUT /orders/{id}", h.Update)
+				mux.HandleFunc("/health", h.Health)
+				mux.HandleFunc("GET /other", other.List)`,
+			want: []httpRoute{
+				{Method: "put", Path: "/orders/{id}", Handler: "Update"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package x\nfunc (h *handler) Configure() {\n" + tt.body + "\n}\n"
+			f, err := parser.ParseFile(token.NewFileSet(), "x.go", src, 0)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			pkg := &handlerPackage{funcs: map[string]*ast.FuncDecl{}, methods: map[string]*ast.FuncDecl{}, structs: map[string]*ast.StructType{}, todo: map[string]bool{}}
+			pkg.collect(f)
+
+			if got := extractRoutes(pkg.methods["Configure"].Body, pkg.receiver); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractRoutes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPIGeneratorGenerate(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	config.ProjectType = "http-server"
+	config.Framework = "chi"
+
+	if err := NewAdapterGenerator(config).generateHTTPHandlerPackage("CategoryHandler", "Category", nil); err != nil {
+		t.Fatalf("generateHTTPHandlerPackage() error = %v", err)
+	}
+
+	structs := []analyzer.DomainStruct{{
+		Name:    "Category",
+		Package: "categories",
+		Fields: []analyzer.FieldInfo{
+			{Name: "ID", Type: "string"},
+			{Name: "Name", Type: "string"},
+			{Name: "Position", Type: "int"},
+			{Name: "CreatedAt", Type: "Time"},
+		},
+	}}
+
+	operations, err := NewOpenAPIGenerator(config).Generate(OpenAPIOptions{
+		Output:    "api/openapi.yaml",
+		Title:     "Demo API",
+		Version:   "0.1.0",
+		BasePath:  "/api/v1",
+		SwaggerUI: true,
+	}, structs)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if operations == 0 {
+		t.Fatalf("Generate() found no operations")
+	}
+
+	spec, err := mem.ReadFile("api/openapi.yaml")
+	if err != nil {
+		t.Fatalf("spec not written: %v", err)
+	}
+	for _, want := range []string{
+		"openapi: 3.0.3",
+		"/categories/{id}:",
+		"operationId: categoriesCreate",
+		"$ref: '#/components/schemas/",
+		"position:",
+		`"404":`,
+	} {
+		if !strings.Contains(string(spec), want) {
+			t.Errorf("spec does not contain %q\n%s", want, spec)
+		}
+	}
+
+	for _, path := range []string{
+		"internal/adapters/primary/http/docs/openapi.yaml",
+		"internal/adapters/primary/http/docs/page.go",
+		"internal/adapters/primary/http/docs/docs.go",
+	} {
+		if !config.fileExists(path) {
+			t.Errorf("expected %s to be generated", path)
+		}
+	}
+}
+
+const importSpec = `openapi: 3.0.3
+info:
+  title: Shop
+  version: 1.0.0
+tags:
+  - name: orders
+    description: Order management
+paths:
+  /api/v1/orders:
+    parameters: []
+    get:
+      tags: [orders]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Order'
+    post:
+      tags: [orders]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/CreateOrderRequest'
+      responses:
+        "201":
+          description: Created
+        "400":
+          description: Bad Request
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /api/v1/orders/{orderId}:
+    delete:
+      tags: [orders]
+      responses:
+        "204":
+          description: No Content
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, total]
+      properties:
+        id:
+          type: string
+        total:
+          type: number
+        created_at:
+          type: string
+          format: date-time
+    CreateOrderRequest:
+      type: object
+      properties:
+        note:
+          type: string
+          nullable: true
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+func TestOpenAPIGeneratorImport(t *testing.T) {
+	mem := fsys.NewMem()
+	for _, dir := range []string{"internal/core/domain", "internal/core/services"} {
+		if err := mem.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := NewProjectConfig("shop", "example.com/shop")
+	config.FS = mem
+	config.Framework = "chi"
+
+	gen := NewOpenAPIGenerator(config)
+	result, err := gen.Import([]byte(importSpec))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	want := &OpenAPIImportResult{
+		Entities: []string{"Order"},
+		Services: []string{"Order"},
+		Handlers: []string{"orders"},
+		Unmapped: []string{"DELETE /api/v1/orders/{orderId}"},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Import() = %+v, want %+v", result, want)
+	}
+
+	files := map[string][]string{
+		"internal/core/domain/orders/orders.go":   {"Total float64", "CreatedAt time.Time"},
+		"internal/core/services/orders/orders.go": {"type OrderService struct"},
+		"internal/adapters/primary/http/orders/order.go": {
+			"Note *string `json:\"note,omitempty\"`",
+			"Total     float64   `json:\"total\"`",
+			"Total:     e.Total,",
+		},
+	}
+	for path, contents := range files {
+		content, err := mem.ReadFile(path)
+		if err != nil {
+			t.Errorf("expected %s to be generated", path)
+			continue
+		}
+		for _, s := range contents {
+			if !strings.Contains(string(content), s) {
+				t.Errorf("%s does not contain %q\n%s", path, s, content)
+			}
+		}
+	}
+
+	// Existing components are skipped on a second import
+	result, err = gen.Import([]byte(importSpec))
+	if err != nil {
+		t.Fatalf("second Import() error = %v", err)
+	}
+	if len(result.Entities)+len(result.Services)+len(result.Handlers) != 0 || len(result.Skipped) != 3 {
+		t.Errorf("second Import() = %+v, want everything skipped", result)
+	}
+
+	if _, err := gen.Import([]byte("swagger: \"2.0\"\n")); err == nil {
+		t.Error("expected an error for a swagger 2.0 spec")
+	}
+}
@@ -0,0 +1,241 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// PortImplementation is a project type implementing a port
+type PortImplementation struct {
+	Type     string `json:"type"`     // e.g. "internal/adapters/secondary/database/orders.OrderRepository"
+	File     string `json:"file"`     // declaring file, relative to the project root
+	Line     int    `json:"line"`     // line of the declaration
+	Adapter  bool   `json:"adapter"`  // declared in internal/adapters
+	Asserted bool   `json:"asserted"` // its package declares var _ Port = (*Type)(nil)
+}
+
+// PortCoverage is a port of the core and the project types implementing it
+type PortCoverage struct {
+	Port            string               `json:"port"` // e.g. "internal/core/domain/orders.OrderRepository"
+	File            string               `json:"file"`
+	Line            int                  `json:"line"`
+	Implementations []PortImplementation `json:"implementations"`
+}
+
+// PortReport maps the ports of the core to the types implementing them, the
+// gaps found as warnings:
+//
+//   - port-implementations: a port no project type implements
+//   - adapter-ports: an outbound adapter type implementing no port
+//   - port-assertions: an adapter type implementing a port without a
+//     compile-time assertion of it
+type PortReport struct {
+	Ports      []PortCoverage `json:"ports"`
+	Violations []Violation    `json:"violations"`
+}
+
+// WriteJSON writes the report as a JSON document
+func (r *PortReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// portType is a named type declared in a project package
+type portType struct {
+	obj      *types.TypeName
+	name     string // directory of the package, relative to the project root, and type name
+	file     string
+	line     int
+	adapter  bool
+	outbound bool
+}
+
+// Ports reports which project types implement the ports of the core:
+// the interfaces with methods declared in internal/core. pkgs hold the type
+// information of the project, as loaded by analyzer.LoadSyntax; test files
+// are left out.
+func Ports(config *ProjectConfig, pkgs []*packages.Package) (*PortReport, error) {
+	root, err := filepath.Abs(config.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	coreImport := config.importPath(filepath.Join("internal", "core"))
+	adaptersImport := config.importPath(filepath.Join("internal", "adapters"))
+	outboundImport := config.importPath(filepath.Join("internal", "adapters", config.AdapterOutboundDir()))
+
+	// Test variants repeat the files of the packages
+	pkgs = slices.DeleteFunc(slices.Clone(pkgs), func(pkg *packages.Package) bool {
+		return pkg.ID != pkg.PkgPath || pkg.Types == nil || pkg.TypesInfo == nil || !withinPath(pkg.PkgPath, config.ModuleName)
+	})
+	slices.SortFunc(pkgs, func(a, b *packages.Package) int { return strings.Compare(a.PkgPath, b.PkgPath) })
+
+	var ports, impls []portType
+	asserted := make(map[[2]*types.TypeName]bool)
+	for _, pkg := range pkgs {
+		dir := strings.TrimPrefix(strings.TrimPrefix(pkg.PkgPath, config.ModuleName), "/")
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			position := pkg.Fset.Position(obj.Pos())
+			file, ok := relativePath(root, position.Filename)
+			if !ok {
+				continue
+			}
+			t := portType{
+				obj:      obj,
+				name:     dir + "." + name,
+				file:     filepath.ToSlash(file),
+				line:     position.Line,
+				adapter:  withinPath(pkg.PkgPath, adaptersImport),
+				outbound: withinPath(pkg.PkgPath, outboundImport),
+			}
+
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if iface, ok := named.Underlying().(*types.Interface); ok {
+				if obj.Exported() && withinPath(pkg.PkgPath, coreImport) && iface.IsMethodSet() && iface.NumMethods() > 0 {
+					ports = append(ports, t)
+				}
+				continue
+			}
+			impls = append(impls, t)
+		}
+
+		for key := range typeAssertions(pkg) {
+			asserted[key] = true
+		}
+	}
+
+	report := &PortReport{Ports: make([]PortCoverage, 0, len(ports)), Violations: make([]Violation, 0)}
+	implementsAny := make(map[*types.TypeName]bool)
+	for _, port := range ports {
+		iface := port.obj.Type().Underlying().(*types.Interface)
+		coverage := PortCoverage{Port: port.name, File: port.file, Line: port.line, Implementations: make([]PortImplementation, 0)}
+
+		for _, impl := range impls {
+			if !implements(impl.obj, iface) {
+				continue
+			}
+			implementsAny[impl.obj] = true
+
+			implementation := PortImplementation{
+				Type:     impl.name,
+				File:     impl.file,
+				Line:     impl.line,
+				Adapter:  impl.adapter,
+				Asserted: asserted[[2]*types.TypeName{port.obj, impl.obj}],
+			}
+			coverage.Implementations = append(coverage.Implementations, implementation)
+
+			if impl.adapter && !implementation.Asserted {
+				report.Violations = append(report.Violations, Violation{
+					Rule:     "port-assertions",
+					Severity: SeverityWarning,
+					File:     impl.file,
+					Line:     impl.line,
+					Message: fmt.Sprintf("%s implements %s without a compile-time assertion: var _ %s = (*%s)(nil)",
+						qualifiedName(impl.obj), qualifiedName(port.obj), qualifiedName(port.obj), impl.obj.Name()),
+				})
+			}
+		}
+
+		if len(coverage.Implementations) == 0 {
+			report.Violations = append(report.Violations, Violation{
+				Rule:     "port-implementations",
+				Severity: SeverityWarning,
+				File:     port.file,
+				Line:     port.line,
+				Message:  fmt.Sprintf("Port %s has no implementation", qualifiedName(port.obj)),
+			})
+		}
+		report.Ports = append(report.Ports, coverage)
+	}
+
+	// Outbound adapters exist to implement ports. Inbound ones drive the
+	// core instead: they call its services.
+	for _, impl := range impls {
+		if !impl.outbound || !impl.obj.Exported() || implementsAny[impl.obj] || !hasExportedMethods(impl.obj) {
+			continue
+		}
+		report.Violations = append(report.Violations, Violation{
+			Rule:     "adapter-ports",
+			Severity: SeverityWarning,
+			File:     impl.file,
+			Line:     impl.line,
+			Message:  fmt.Sprintf("Adapter %s implements no port", qualifiedName(impl.obj)),
+		})
+	}
+
+	return report, nil
+}
+
+// typeAssertions returns the interface and implementation type of the
+// compile-time assertions declared in pkg: var _ Port = (*Impl)(nil)
+func typeAssertions(pkg *packages.Package) map[[2]*types.TypeName]bool {
+	assertions := make(map[[2]*types.TypeName]bool)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if spec.Type == nil || len(spec.Names) != 1 || spec.Names[0].Name != "_" || len(spec.Values) != 1 {
+					continue
+				}
+				port := namedObject(pkg.TypesInfo.TypeOf(spec.Type))
+				impl := namedObject(pkg.TypesInfo.TypeOf(spec.Values[0]))
+				if port != nil && impl != nil {
+					assertions[[2]*types.TypeName{port, impl}] = true
+				}
+			}
+		}
+	}
+	return assertions
+}
+
+// namedObject returns the type name of t, or of the type t points to
+func namedObject(t types.Type) *types.TypeName {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj()
+	}
+	return nil
+}
+
+// hasExportedMethods reports whether the type of obj, or a pointer to it,
+// has exported methods
+func hasExportedMethods(obj *types.TypeName) bool {
+	methods := types.NewMethodSet(types.NewPointer(obj.Type()))
+	for i := range methods.Len() {
+		if methods.At(i).Obj().Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedName returns the name of obj qualified by its package name,
+// e.g. "orders.OrderRepository"
+func qualifiedName(obj *types.TypeName) string {
+	return obj.Pkg().Name() + "." + obj.Name()
+}
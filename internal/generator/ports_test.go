@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestPorts(t *testing.T) {
+	dir := t.TempDir()
+	disk := fsys.NewOS(dir)
+
+	for path, content := range map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.21\n",
+		"internal/core/domain/orders/order.go": `package orders
+
+// Order is a customer order
+type Order struct {
+	ID string
+}
+`,
+		"internal/core/domain/orders/port.go": `package orders
+
+import "context"
+
+// OrderRepository defines the secondary port for Order persistence
+type OrderRepository interface {
+	Save(ctx context.Context, order *Order) error
+}
+
+// Notifier defines the secondary port for order notifications
+type Notifier interface {
+	Notify(ctx context.Context, order *Order) error
+}
+
+// Event marks the events of an order
+type Event interface{}
+`,
+		"internal/adapters/secondary/database/orders/orders.go": `package orders
+
+import (
+	"context"
+
+	ordersDomain "example.com/demo/internal/core/domain/orders"
+)
+
+var _ ordersDomain.OrderRepository = (*Repository)(nil)
+
+type Repository struct{}
+
+func (r *Repository) Save(ctx context.Context, order *ordersDomain.Order) error {
+	return nil
+}
+`,
+		"internal/adapters/secondary/cache/cache.go": `package cache
+
+import (
+	"context"
+
+	"example.com/demo/internal/core/domain/orders"
+)
+
+type Cache struct{}
+
+func (c Cache) Save(ctx context.Context, order *orders.Order) error {
+	return nil
+}
+`,
+		"internal/adapters/secondary/mail/mail.go": `package mail
+
+type Mailer struct{}
+
+func (m *Mailer) Send(to, body string) error {
+	return nil
+}
+`,
+		"internal/adapters/primary/http/http.go": `package http
+
+import "net/http"
+
+type Handler struct{}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+`,
+	} {
+		mustWrite(t, disk, path, content)
+	}
+
+	pkgs, err := analyzer.LoadSyntax(dir)
+	if err != nil {
+		t.Fatalf("LoadSyntax() error = %v", err)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.OutputDir = dir
+	config.FS = disk
+
+	report, err := Ports(config, pkgs)
+	if err != nil {
+		t.Fatalf("Ports() error = %v", err)
+	}
+
+	type implementation struct {
+		port, impl string
+		asserted   bool
+	}
+	var got []implementation
+	for _, port := range report.Ports {
+		if len(port.Implementations) == 0 {
+			got = append(got, implementation{port: port.Port})
+		}
+		for _, impl := range port.Implementations {
+			got = append(got, implementation{port.Port, impl.Type, impl.Asserted})
+		}
+	}
+	want := []implementation{
+		{port: "internal/core/domain/orders.Notifier"},
+		{"internal/core/domain/orders.OrderRepository", "internal/adapters/secondary/cache.Cache", false},
+		{"internal/core/domain/orders.OrderRepository", "internal/adapters/secondary/database/orders.Repository", true},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Ports() implementations = %+v, want %+v", got, want)
+	}
+
+	wantViolations := []string{
+		"port-implementations: Port orders.Notifier has no implementation in internal/core/domain/orders/port.go:11",
+		"port-assertions: cache.Cache implements orders.OrderRepository without a compile-time assertion: var _ orders.OrderRepository = (*Cache)(nil) in internal/adapters/secondary/cache/cache.go:9",
+		"adapter-ports: Adapter mail.Mailer implements no port in internal/adapters/secondary/mail/mail.go:3",
+	}
+	if got := violationStrings(report.Violations); !slices.Equal(got, wantViolations) {
+		t.Errorf("Ports() violations = %q, want %q", got, wantViolations)
+	}
+}
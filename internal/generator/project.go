@@ -5,14 +5,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-
-	"github.com/padiazg/hexago/pkg/utils"
 )
 
 // ProjectGenerator handles the generation of new projects
 type ProjectGenerator struct {
 	config      *ProjectConfig
-	projectPath string
+	projectPath string          // relative to config.OutputDir
+	generated   []generatedFile // files rendered by generateFile
+	maintained  []generatedFile // files regenerated by later commands, recorded without a base
 }
 
 // NewProjectGenerator creates a new ProjectGenerator
@@ -22,23 +22,53 @@ func NewProjectGenerator(config *ProjectConfig) *ProjectGenerator {
 	}
 }
 
-// Generate creates the complete project structure
+// Generate creates the complete project structure.
+// Files are staged in memory and committed together; if any step fails,
+// including the go toolchain steps, everything created is rolled back.
 func (g *ProjectGenerator) Generate() error {
-	// var projectPath string
 	if g.config.InPlace {
-		g.projectPath = g.config.OutputDir
+		g.projectPath = "."
 	} else {
-		g.projectPath = filepath.Join(g.config.OutputDir, g.config.ProjectName)
+		g.projectPath = g.config.ProjectName
 		// Check if directory already exists (in-place always uses an existing dir)
-		if utils.FileExists(g.projectPath) {
-			return fmt.Errorf("directory %s already exists", g.projectPath)
+		if g.config.fileExists(g.projectPath) {
+			return fmt.Errorf("directory %s already exists", g.goDir())
 		}
 	}
 
 	fmt.Printf("🚀 Generating project %s...\n", g.config.ProjectName)
 
+	tx, err := g.config.stage(g.generateProject)
+	if err != nil {
+		return err
+	}
+
+	// The go toolchain steps need real files on disk
+	if g.config.DryRun {
+		fmt.Println("ℹ️  Dry run: skipping go mod init, go mod tidy and go fmt")
+		return nil
+	}
+
+	// Files created by the go toolchain must be removed on rollback too
+	tx.Track(filepath.Join(g.projectPath, "go.mod"))
+	tx.Track(filepath.Join(g.projectPath, "go.sum"))
+
+	if err := g.runGoToolchain(); err != nil {
+		fmt.Println("↩️  Rolling back generated files...")
+		if rbErr := tx.Rollback(); rbErr != nil {
+			fmt.Printf("⚠️  Warning: rollback incomplete: %v\n", rbErr)
+		}
+		return err
+	}
+
+	g.printSuccess()
+	return nil
+}
+
+// generateProject stages the directory structure and every project file
+func (g *ProjectGenerator) generateProject() error {
 	// Create base directory (no-op when in-place, dir already exists)
-	if err := utils.CreateDir(g.projectPath); err != nil {
+	if err := g.config.createDir(g.projectPath); err != nil {
 		return fmt.Errorf("failed to create project directory: %w", err)
 	}
 
@@ -48,10 +78,22 @@ func (g *ProjectGenerator) Generate() error {
 	}
 
 	// Generate files from templates
+	fmt.Println("📝 Generating files...")
 	if err := g.generateFiles(); err != nil {
 		return fmt.Errorf("failed to generate files: %w", err)
 	}
 
+	// Write .hexago.yaml to persist init-time settings
+	if err := g.saveHexagoConfig(); err != nil {
+		fmt.Printf("⚠️  Warning: failed to write .hexago.yaml: %v\n", err)
+		// non-fatal — project is still fully usable
+	}
+
+	return nil
+}
+
+// runGoToolchain initializes the go module, resolves dependencies and formats the code
+func (g *ProjectGenerator) runGoToolchain() error {
 	// Initialize go.mod
 	if err := g.initGoModule(); err != nil {
 		return fmt.Errorf("failed to initialize go module: %w", err)
@@ -68,13 +110,6 @@ func (g *ProjectGenerator) Generate() error {
 		fmt.Printf("⚠️  Warning: failed to format code: %v\n", err)
 	}
 
-	// Write .hexago.yaml to persist init-time settings
-	if err := g.saveHexagoConfig(); err != nil {
-		fmt.Printf("⚠️  Warning: failed to write .hexago.yaml: %v\n", err)
-		// non-fatal — project is still fully usable
-	}
-
-	g.printSuccess()
 	return nil
 }
 
@@ -82,11 +117,17 @@ func (g *ProjectGenerator) Generate() error {
 func (g *ProjectGenerator) generateDirectoryStructure() error {
 	fmt.Println("📁 Creating directory structure...")
 
+	// CLI applications are driven by commands instead of HTTP requests
+	inboundAdapter := "http"
+	if g.config.IsCLI() {
+		inboundAdapter = "cli"
+	}
+
 	dirs := []string{
 		"cmd",
 		"internal/core/domain",
 		fmt.Sprintf("internal/core/%s", g.config.CoreLogicDir()),
-		fmt.Sprintf("internal/adapters/%s/http", g.config.AdapterInboundDir()),
+		fmt.Sprintf("internal/adapters/%s/%s", g.config.AdapterInboundDir(), inboundAdapter),
 		fmt.Sprintf("internal/adapters/%s/database", g.config.AdapterOutboundDir()),
 		"internal/config",
 		"pkg/logger",
@@ -114,13 +155,11 @@ func (g *ProjectGenerator) generateDirectoryStructure() error {
 	}
 
 	// Create all directories
-	return utils.CreateDirs(g.projectPath, dirs)
+	return g.config.createDirs(g.projectPath, dirs)
 }
 
 // generateFiles generates all files from templates
 func (g *ProjectGenerator) generateFiles() error {
-	fmt.Println("📝 Generating files...")
-
 	// Generate main.go
 	if err := g.generateFile(mainTemplate); err != nil {
 		return err
@@ -145,10 +184,24 @@ func (g *ProjectGenerator) generateFiles() error {
 
 	// Generate processor for service type
 	case "service":
+		if err := g.generateFile(servicesStubTemplate); err != nil {
+			return err
+		}
+
 		if err := g.generateFile(processorTemplate); err != nil {
 			return err
 		}
 
+		// Generate job for job type
+	case "job":
+		if err := g.generateFile(servicesStubTemplate); err != nil {
+			return err
+		}
+
+		if err := g.generateFile(jobTemplate); err != nil {
+			return err
+		}
+
 		// Generate pkg/httpserver and adapter wiring (http-server type only)
 	case "http-server":
 		if err := g.generateFile(servicesStubTemplate); err != nil {
@@ -167,9 +220,32 @@ func (g *ProjectGenerator) generateFiles() error {
 			return err
 		}
 
+		if err := g.generateRoutes(); err != nil {
+			return err
+		}
+
 		if err := g.generateFile(httpPingTemplate); err != nil {
 			return err
 		}
+
+		// Generate CLI adapter with its example command (cli type only)
+	case "cli":
+		if err := g.generateFile(servicesStubTemplate); err != nil {
+			return err
+		}
+
+		if err := g.generateFile(cliAdapterTemplate); err != nil {
+			return err
+		}
+
+		if err := g.generateFile(cliHelloTemplate); err != nil {
+			return err
+		}
+	}
+
+	// Generate the composition root (every project type)
+	if err := g.generateWiring(); err != nil {
+		return err
 	}
 
 	// Generate config
@@ -248,7 +324,7 @@ func (g *ProjectGenerator) initGoModule() error {
 	fmt.Println("📦 Initializing go module...")
 
 	cmd := exec.Command("go", "mod", "init", g.config.ModuleName)
-	cmd.Dir = g.projectPath
+	cmd.Dir = g.goDir()
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -294,7 +370,7 @@ func (g *ProjectGenerator) addDependencies() error {
 
 	for _, dep := range dependencies {
 		cmd := exec.Command("go", "get", dep)
-		cmd.Dir = g.projectPath
+		cmd.Dir = g.goDir()
 		if err := cmd.Run(); err != nil {
 			fmt.Printf("⚠️  Warning: failed to add dependency %s: %v\n", dep, err)
 		}
@@ -308,7 +384,7 @@ func (g *ProjectGenerator) runGoModTidy() error {
 	fmt.Println("🧹 Running go mod tidy...")
 
 	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir = g.projectPath
+	cmd.Dir = g.goDir()
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -319,12 +395,37 @@ func (g *ProjectGenerator) runGoModTidy() error {
 	return nil
 }
 
+// generateRoutes generates routes.go with empty hexago:routes regions
+func (g *ProjectGenerator) generateRoutes() error {
+	templ := g.config.routesTemplate()
+	content, err := g.config.renderRoutes(nil)
+	if err != nil {
+		return err
+	}
+	g.generated = append(g.generated, generatedFile{templateItem: templ, content: content})
+
+	return g.config.writeFile(filepath.Join(g.projectPath, templ.target), content)
+}
+
+// generateWiring generates the composition root. It is regenerated as
+// components are added, so it has no base for upgrades to merge with.
+func (g *ProjectGenerator) generateWiring() error {
+	content, err := NewWiringGenerator(g.config).render(g.projectPath)
+	if err != nil {
+		return err
+	}
+	templ := templateItem{source: "app/wire_gen.go.tmpl", target: WiringFile}
+	g.maintained = append(g.maintained, generatedFile{templateItem: templ, content: content})
+
+	return g.config.writeFile(filepath.Join(g.projectPath, templ.target), content)
+}
+
 // formatCode runs go fmt on the generated code
 func (g *ProjectGenerator) formatCode() error {
 	fmt.Println("✨ Formatting code...")
 
 	cmd := exec.Command("go", "fmt", "./...")
-	cmd.Dir = g.projectPath
+	cmd.Dir = g.goDir()
 
 	if err := cmd.Run(); err != nil {
 		return err
@@ -333,10 +434,27 @@ func (g *ProjectGenerator) formatCode() error {
 	return nil
 }
 
-// saveHexagoConfig writes .hexago.yaml with the current project settings.
+// goDir returns the project directory on disk, used to run the go toolchain
+func (g *ProjectGenerator) goDir() string {
+	return filepath.Join(g.config.OutputDir, g.projectPath)
+}
+
+// saveHexagoConfig writes .hexago.yaml with the current project settings
+// and the record of the generated files.
 func (g *ProjectGenerator) saveHexagoConfig() error {
-	cfg := HexagoConfigFromProject(g.config)
-	return SaveHexagoConfig(g.projectPath, cfg)
+	hexCfg := HexagoConfigFromProject(g.config)
+
+	record, err := g.recordGenerated()
+	if err != nil {
+		return err
+	}
+	hexCfg.Generated = *record
+
+	content, err := MarshalHexagoConfig(hexCfg)
+	if err != nil {
+		return err
+	}
+	return g.config.writeFile(filepath.Join(g.projectPath, HexagoConfigFile), content)
 }
 
 // printSuccess prints success message with next steps
@@ -344,6 +462,10 @@ func (g *ProjectGenerator) printSuccess() {
 	fmt.Println("\n✅ Project generated successfully!")
 	fmt.Println("\n📚 Next steps:")
 	fmt.Printf("  cd %s\n", g.config.ProjectName)
-	fmt.Println("  go run main.go run")
+	if g.config.IsCLI() {
+		fmt.Println("  go run main.go hello")
+	} else {
+		fmt.Println("  go run main.go run")
+	}
 	fmt.Println("\n📖 Read the README.md for more information about the project structure.")
 }
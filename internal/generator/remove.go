@@ -0,0 +1,389 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// removal lists what a remove command deletes
+type removal struct {
+	dirs  []string // package directories, deleted with everything in them
+	files []string // files deleted from packages that stay
+	// rewritten are the packages the removal regenerates (e.g. the services
+	// aggregator), not reported as still importing the deleted ones
+	rewritten []string
+}
+
+// Remove deletes the sub-package of a service and regenerates the
+// services aggregator without it. Packages still importing the service
+// according to imports are reported; a nil imports skips the check.
+func (g *ServiceGenerator) Remove(serviceName string, imports analyzer.ImportGraph) error {
+	return g.config.transact(func() error {
+		return g.remove(serviceName, imports)
+	})
+}
+
+// remove does the work of Remove inside its transaction
+func (g *ServiceGenerator) remove(serviceName string, imports analyzer.ImportGraph) error {
+	baseServiceDir := filepath.Join("internal", "core", g.config.CoreLogicDir())
+	serviceDir, ok := g.config.findPackageDir(baseServiceDir, serviceName)
+	if !ok {
+		return fmt.Errorf("service %s not found in %s", serviceName, baseServiceDir)
+	}
+
+	r := removal{dirs: []string{serviceDir}, rewritten: []string{baseServiceDir}}
+	if err := g.config.remove(r, imports); err != nil {
+		return err
+	}
+
+	if err := g.upsertAggregator(baseServiceDir); err != nil {
+		fmt.Printf("⚠️  Warning: failed to update services aggregator: %v\n", err)
+	}
+
+	return nil
+}
+
+// RemovePrimary deletes a primary (inbound) adapter: its file and test, or
+// the per-entity sub-package of HTTP and gRPC adapters
+func (g *AdapterGenerator) RemovePrimary(adapterType, adapterName string, imports analyzer.ImportGraph) error {
+	return g.config.transact(func() error {
+		return g.removePrimary(adapterType, adapterName, imports)
+	})
+}
+
+// removePrimary does the work of RemovePrimary inside its transaction
+func (g *AdapterGenerator) removePrimary(adapterType, adapterName string, imports analyzer.ImportGraph) error {
+	if !slices.Contains([]string{"http", "grpc", "queue", "cli"}, adapterType) {
+		return fmt.Errorf("invalid primary adapter type '%s'. Valid types: http, grpc, queue, cli", adapterType)
+	}
+
+	adapterDir := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), adapterType)
+	if files, ok := g.config.componentFiles(adapterDir, adapterName); ok {
+		return g.config.remove(removal{files: files}, imports)
+	}
+
+	pkgDir, ok := g.config.findPackageDir(adapterDir, adapterName)
+	if !ok || (adapterType != "http" && adapterType != "grpc") {
+		return fmt.Errorf("%s adapter %s not found in %s", adapterType, adapterName, adapterDir)
+	}
+
+	r := removal{dirs: []string{pkgDir}}
+	if adapterType == "http" {
+		// routes.go drops the handler package when regenerated
+		if g.config.fileExists(g.config.routesTemplate().target) {
+			r.rewritten = []string{adapterDir}
+		}
+		if err := g.config.remove(r, imports); err != nil {
+			return err
+		}
+		return g.upsertRoutes()
+	}
+
+	// gRPC: the .proto, its generated code and the registration in grpc.go
+	pkgName := filepath.Base(pkgDir)
+	for _, dir := range []string{filepath.Join("api", "proto", pkgName), filepath.Join("gen", "proto", pkgName)} {
+		if g.config.isDir(dir) {
+			r.dirs = append(r.dirs, dir)
+		}
+	}
+	r.rewritten = []string{adapterDir}
+	if err := g.config.remove(r, imports); err != nil {
+		return err
+	}
+
+	return g.upsertGRPCAdapter(adapterDir)
+}
+
+// RemoveSecondary deletes a secondary (outbound) adapter: the sub-package
+// of a database adapter, or the file and test of the others
+func (g *AdapterGenerator) RemoveSecondary(adapterType, adapterName string, imports analyzer.ImportGraph) error {
+	return g.config.transact(func() error {
+		return g.removeSecondary(adapterType, adapterName, imports)
+	})
+}
+
+// removeSecondary does the work of RemoveSecondary inside its transaction
+func (g *AdapterGenerator) removeSecondary(adapterType, adapterName string, imports analyzer.ImportGraph) error {
+	if !slices.Contains([]string{"database", "external", "cache"}, adapterType) {
+		return fmt.Errorf("invalid secondary adapter type '%s'. Valid types: database, external, cache", adapterType)
+	}
+
+	adapterDir := filepath.Join("internal", "adapters", g.config.AdapterOutboundDir(), adapterType)
+	if adapterType == "database" {
+		pkgDir, ok := g.config.findPackageDir(adapterDir, adapterName)
+		if !ok {
+			return fmt.Errorf("database adapter %s not found in %s", adapterName, adapterDir)
+		}
+		r := removal{dirs: []string{pkgDir}, rewritten: []string{filepath.Dir(WiringFile)}}
+		if err := g.config.remove(r, imports); err != nil {
+			return err
+		}
+		return g.config.rewire()
+	}
+
+	files, ok := g.config.componentFiles(adapterDir, adapterName)
+	if !ok {
+		return fmt.Errorf("%s adapter %s not found in %s", adapterType, adapterName, adapterDir)
+	}
+	return g.config.remove(removal{files: files}, imports)
+}
+
+// RemoveEntity deletes the sub-package of a domain entity, with its port
+// and value objects
+func (g *DomainGenerator) RemoveEntity(entityName string, imports analyzer.ImportGraph) error {
+	return g.config.transact(func() error {
+		return g.removeEntity(entityName, imports)
+	})
+}
+
+// removeEntity does the work of RemoveEntity inside its transaction
+func (g *DomainGenerator) removeEntity(entityName string, imports analyzer.ImportGraph) error {
+	domainDir := filepath.Join("internal", "core", "domain")
+	entityDir, ok := g.config.findPackageDir(domainDir, entityName)
+	if !ok {
+		return fmt.Errorf("entity %s not found in %s", entityName, domainDir)
+	}
+	return g.config.remove(removal{dirs: []string{entityDir}}, imports)
+}
+
+// RemoveValueObject deletes a value object: its file and test from the
+// entity sub-package when entityName is set, its own sub-package otherwise
+func (g *DomainGenerator) RemoveValueObject(voName, entityName string, imports analyzer.ImportGraph) error {
+	return g.config.transact(func() error {
+		return g.removeValueObject(voName, entityName, imports)
+	})
+}
+
+// removeValueObject does the work of RemoveValueObject inside its transaction
+func (g *DomainGenerator) removeValueObject(voName, entityName string, imports analyzer.ImportGraph) error {
+	domainDir := filepath.Join("internal", "core", "domain")
+
+	if entityName != "" {
+		entityDir, ok := g.config.findPackageDir(domainDir, entityName)
+		if !ok {
+			return fmt.Errorf("entity %s not found in %s", entityName, domainDir)
+		}
+		files, ok := g.config.componentFiles(entityDir, voName)
+		if !ok {
+			return fmt.Errorf("value object %s not found in %s", voName, entityDir)
+		}
+		return g.config.remove(removal{files: files}, imports)
+	}
+
+	voDir := filepath.Join(domainDir, strings.ToLower(voName))
+	if !g.config.isDir(voDir) {
+		return fmt.Errorf("value object %s not found in %s", voName, domainDir)
+	}
+	return g.config.remove(removal{dirs: []string{voDir}}, imports)
+}
+
+// Remove deletes a worker and its test
+func (g *WorkerGenerator) Remove(workerName string) error {
+	return g.config.transact(func() error {
+		return g.remove(workerName)
+	})
+}
+
+// remove does the work of Remove inside its transaction
+func (g *WorkerGenerator) remove(workerName string) error {
+	workersDir := filepath.Join("internal", "workers")
+	files, ok := g.config.componentFiles(workersDir, workerName)
+	if !ok {
+		return fmt.Errorf("worker %s not found in %s", workerName, workersDir)
+	}
+	if err := g.config.remove(removal{files: files}, nil); err != nil {
+		return err
+	}
+
+	return g.config.rewire()
+}
+
+// Remove deletes an infrastructure tool and its test
+func (g *ToolGenerator) Remove(toolType, toolName string) error {
+	return g.config.transact(func() error {
+		return g.remove(toolType, toolName)
+	})
+}
+
+// remove does the work of Remove inside its transaction
+func (g *ToolGenerator) remove(toolType, toolName string) error {
+	toolDir := filepath.Join("internal", "infrastructure", toolType)
+	files, ok := g.config.componentFiles(toolDir, toolName)
+	if !ok {
+		return fmt.Errorf("%s tool %s not found in %s", toolType, toolName, toolDir)
+	}
+	return g.config.remove(removal{files: files}, nil)
+}
+
+// Remove deletes the files of a migration, given its version or name. It is
+// meant for migrations that were not applied to any database yet.
+func (g *MigrationGenerator) Remove(migration string) error {
+	return g.config.transact(func() error {
+		return g.remove(migration)
+	})
+}
+
+// remove does the work of Remove inside its transaction
+func (g *MigrationGenerator) remove(migration string) error {
+	migrationsDir := "migrations"
+	entries, err := g.config.readDirNames(migrationsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	version, byVersion := strconv.ParseUint(migration, 10, 64)
+	nameFile := regexp.MustCompile(`^\d+_` + regexp.QuoteMeta(migration) + `\.(up\.sql|down\.sql|go)$`)
+
+	var files []string
+	versions := make(map[string]bool)
+	for _, entry := range entries {
+		m := migrationVersionPattern.FindStringSubmatch(entry)
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.ParseUint(m[1], 10, 64)
+		if (byVersion == nil && n == version) || nameFile.MatchString(entry) {
+			files = append(files, filepath.Join(migrationsDir, entry))
+			versions[m[1]] = true
+		}
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("migration %s not found in %s", migration, migrationsDir)
+	}
+	if len(versions) > 1 {
+		return fmt.Errorf("%d migrations are named %s; remove one by version", len(versions), migration)
+	}
+
+	return g.config.remove(removal{files: files}, nil)
+}
+
+// migrationVersionPattern matches the files of a migration, SQL or Go
+var migrationVersionPattern = regexp.MustCompile(`^(\d+)_.*\.(up\.sql|down\.sql|go)$`)
+
+// findPackageDir returns the sub-package of base a component named name was
+// generated in: its lowercase name, or the plural entity packages use
+func (c *ProjectConfig) findPackageDir(base, name string) (string, bool) {
+	lower := strings.ToLower(name)
+	for _, pkgName := range []string{lower, utils.ToPlural(lower)} {
+		if dir := filepath.Join(base, pkgName); c.isDir(dir) {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// componentFiles returns the file of a component generated in dir, and its
+// test when there is one
+func (c *ProjectConfig) componentFiles(dir, name string) ([]string, bool) {
+	file := filepath.Join(dir, utils.ToSnakeCase(name)+".go")
+	if !c.fileExists(file) {
+		return nil, false
+	}
+
+	files := []string{file}
+	if test := filepath.Join(dir, utils.ToSnakeCase(name)+"_test.go"); c.fileExists(test) {
+		files = append(files, test)
+	}
+	return files, true
+}
+
+// remove deletes the directories and files of r and drops them from the
+// manifest. It warns about files hexago did not generate or that were
+// edited since, and about packages still importing a deleted one.
+func (c *ProjectConfig) remove(r removal, imports analyzer.ImportGraph) error {
+	var files, dirs []string
+	for _, dir := range r.dirs {
+		err := fsys.WalkDir(c.filesystem(), dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				dirs = append(dirs, path)
+			} else {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	files = append(files, r.files...)
+
+	c.warnImporters(r, imports)
+
+	recorded := c.recordedHashes()
+	for _, path := range files {
+		if recorded != nil {
+			content, _ := c.readFile(path)
+			switch hash, ok := recorded[path]; {
+			case !ok:
+				fmt.Printf("⚠️  Warning: %s was not generated by hexago\n", path)
+			case hash != hashContent(content):
+				fmt.Printf("⚠️  Warning: %s was modified since it was generated\n", path)
+			}
+		}
+
+		fmt.Printf("🗑️  Removing %s\n", path)
+		if err := c.removeFile(path); err != nil {
+			return err
+		}
+		c.unrecordFile(path)
+	}
+
+	// Walked parents first: remove them last
+	slices.Reverse(dirs)
+	for _, dir := range dirs {
+		if err := c.removeFile(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// warnImporters reports the packages that still import a package deleted
+// by r, or one of its sub-packages
+func (c *ProjectConfig) warnImporters(r removal, imports analyzer.ImportGraph) {
+	if imports == nil {
+		return
+	}
+
+	within := func(pkg string, dirs []string) bool {
+		for _, dir := range dirs {
+			if p := c.importPath(dir); pkg == p || strings.HasPrefix(pkg, p+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	var warnings []string
+	for pkg, importers := range imports {
+		if !within(pkg, r.dirs) {
+			continue
+		}
+		for _, importer := range importers {
+			if within(importer, r.dirs) || slices.ContainsFunc(r.rewritten, func(dir string) bool { return c.importPath(dir) == importer }) {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("⚠️  Warning: %s still imports %s",
+				strings.TrimPrefix(importer, c.ModuleName+"/"), strings.TrimPrefix(pkg, c.ModuleName+"/")))
+		}
+	}
+
+	slices.Sort(warnings)
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestServiceGeneratorRemove(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	config.InPlace = true
+
+	gen := NewProjectGenerator(config)
+	gen.projectPath = "."
+	if err := config.transact(gen.generateProject); err != nil {
+		t.Fatalf("generateProject() error = %v", err)
+	}
+
+	services := NewServiceGenerator(config)
+	for _, entity := range []string{"Category", "Product"} {
+		if err := services.Generate(entity, entity, "", nil); err != nil {
+			t.Fatalf("Generate(%s) error = %v", entity, err)
+		}
+	}
+
+	imports := analyzer.ImportGraph{
+		"example.com/demo/internal/core/services/categories": {
+			"example.com/demo/internal/adapters/primary/http/categories",
+			"example.com/demo/internal/core/services",
+		},
+	}
+	if err := services.Remove("Category", imports); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if fsys.Exists(mem, "internal/core/services/categories") {
+		t.Error("internal/core/services/categories not removed")
+	}
+	aggregator := string(mustRead(t, mem, "internal/core/services/services.go"))
+	if strings.Contains(aggregator, "categories") || !strings.Contains(aggregator, "productsSvc") {
+		t.Errorf("services.go not regenerated:\n%s", aggregator)
+	}
+
+	hexCfg, err := parseHexagoConfig(mustRead(t, mem, HexagoConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range hexCfg.Generated.Files {
+		if strings.Contains(f.Path, "categories") {
+			t.Errorf("%s still in the manifest", f.Path)
+		}
+	}
+
+	// Removing the last service brings back the stub
+	if err := services.Remove("Product", nil); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if got := string(mustRead(t, mem, "internal/core/services/services.go")); strings.Contains(got, "products") {
+		t.Errorf("services.go still references products:\n%s", got)
+	}
+
+	if err := services.Remove("Order", nil); err == nil {
+		t.Error("expected an error for a missing service")
+	}
+}
+
+func TestMigrationGeneratorRemove(t *testing.T) {
+	mem := fsys.NewMem()
+	for _, name := range []string{
+		"000001_create_users.up.sql",
+		"000001_create_users.down.sql",
+		"000002_seed_users.go",
+		"000003_add_email.up.sql",
+		"000003_add_email.down.sql",
+		"migrations.go",
+	} {
+		mustWrite(t, mem, "migrations/"+name, "")
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	gen := NewMigrationGenerator(config)
+
+	if err := gen.Remove("add_email"); err != nil {
+		t.Fatalf("Remove(add_email) error = %v", err)
+	}
+	if err := gen.Remove("2"); err != nil {
+		t.Fatalf("Remove(2) error = %v", err)
+	}
+	if err := gen.Remove("add_email"); err == nil {
+		t.Error("expected an error for a missing migration")
+	}
+
+	got, _ := fsys.ReadDirNames(mem, "migrations")
+	want := []string{"000001_create_users.down.sql", "000001_create_users.up.sql", "migrations.go"}
+	if !slices.Equal(got, want) {
+		t.Errorf("migrations = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,323 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+	"github.com/padiazg/hexago/pkg/utils"
+	"golang.org/x/tools/go/packages"
+)
+
+// entityNames are the names hexago derives from an entity name
+type entityNames struct {
+	Type    string // e.g. "Category"
+	Var     string // e.g. "category"
+	Package string // e.g. "categories"
+	Field   string // e.g. "Categories", the field of the services aggregator
+}
+
+// newEntityNames returns the names derived from entityName
+func newEntityNames(entityName string) entityNames {
+	pkgName := utils.ToPlural(strings.ToLower(entityName))
+	return entityNames{
+		Type:    entityName,
+		Var:     strings.ToLower(entityName[:1]) + entityName[1:],
+		Package: pkgName,
+		Field:   utils.ToTitleCase(pkgName),
+	}
+}
+
+// renameIdentifier replaces the names derived from one entity name in an
+// identifier with the ones derived from another. A name matches where a
+// word of the identifier starts with it: anywhere for the exported names,
+// at the start for the unexported ones ("categoriesDomain",
+// "toCategoryResponse", "CreateCategoryInput").
+func renameIdentifier(ident string, from, to entityNames) string {
+	type pair struct{ from, to string }
+	exported := []pair{{from.Field, to.Field}, {from.Type, to.Type}}
+	unexported := []pair{{from.Package, to.Package}, {from.Var, to.Var}}
+
+	// The longest names first: "Categories" before "Category"
+	sortPairs := func(pairs []pair) {
+		slices.SortStableFunc(pairs, func(a, b pair) int { return len(b.from) - len(a.from) })
+	}
+	sortPairs(exported)
+	sortPairs(unexported)
+
+	endsWord := func(rest string) bool {
+		return rest == "" || !unicode.IsLower(rune(rest[0]))
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(ident); {
+		candidates := exported
+		if i == 0 {
+			candidates = append(slices.Clone(unexported), exported...)
+		}
+
+		matched := false
+		for _, p := range candidates {
+			if strings.HasPrefix(ident[i:], p.from) && endsWord(ident[i+len(p.from):]) {
+				b.WriteString(p.to)
+				i += len(p.from)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(ident[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// RenameEntity renames a domain entity across the layers hexago generates
+// for it: the domain, service, database adapter, HTTP and gRPC handler
+// sub-packages move to the package of the new name, and the identifiers
+// derived from the entity name are renamed along with their uses, with the
+// type information of pkgs. The services aggregator is rebuilt.
+func (g *DomainGenerator) RenameEntity(oldName, newName string, pkgs []*packages.Package) error {
+	return g.config.transact(func() error {
+		return g.renameEntity(oldName, newName, pkgs)
+	})
+}
+
+// renameEntity does the work of RenameEntity inside its transaction
+func (g *DomainGenerator) renameEntity(oldName, newName string, pkgs []*packages.Package) error {
+	if !token.IsIdentifier(newName) || !token.IsExported(newName) {
+		return fmt.Errorf("invalid entity name '%s': must be a PascalCase Go identifier", newName)
+	}
+	if oldName == newName {
+		return fmt.Errorf("entity is already named %s", newName)
+	}
+
+	from, to := newEntityNames(oldName), newEntityNames(newName)
+
+	domainDir := filepath.Join("internal", "core", "domain")
+	baseServiceDir := filepath.Join("internal", "core", g.config.CoreLogicDir())
+	bases := []string{
+		domainDir,
+		baseServiceDir,
+		filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "http"),
+		filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "grpc"),
+		filepath.Join("internal", "adapters", g.config.AdapterOutboundDir(), "database"),
+	}
+
+	dirs := make(map[string]string)
+	moves := make(map[string]string)
+	for _, base := range bases {
+		oldDir := filepath.Join(base, from.Package)
+		if !g.config.isDir(oldDir) {
+			continue
+		}
+		newDir := filepath.Join(base, to.Package)
+		if newDir != oldDir && g.config.fileExists(newDir) {
+			return fmt.Errorf("%s already exists", newDir)
+		}
+		dirs[oldDir] = newDir
+		moves[g.config.importPath(oldDir)] = g.config.importPath(newDir)
+	}
+	if _, ok := dirs[filepath.Join(domainDir, from.Package)]; !ok {
+		return fmt.Errorf("entity %s not found in %s", oldName, domainDir)
+	}
+
+	rename := func(ident string) string { return renameIdentifier(ident, from, to) }
+	scope := []string{g.config.importPath(baseServiceDir)}
+	edits, err := g.config.relativeEdits(analyzer.RenameEdits(pkgs, moves, scope, rename))
+	if err != nil {
+		return err
+	}
+
+	recorded := g.config.recordedFiles()
+	_, rebuild := dirs[filepath.Join(baseServiceDir, from.Package)]
+	aggregatorPath := filepath.Join(baseServiceDir, "services.go")
+
+	// Files outside the moved packages that use them
+	err = g.config.editFiles(edits, recorded, func(path string) bool {
+		return inDirs(path, dirs) || (rebuild && (path == aggregatorPath || path == WiringFile))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, oldDir := range slices.Sorted(maps.Keys(dirs)) {
+		if err := g.config.moveEntityPackage(oldDir, dirs[oldDir], from, to, edits, recorded); err != nil {
+			return err
+		}
+	}
+
+	if rebuild {
+		if err := NewServiceGenerator(g.config).upsertAggregator(baseServiceDir); err != nil {
+			fmt.Printf("⚠️  Warning: failed to update services aggregator: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// moveEntityPackage moves an entity sub-package from oldDir to newDir,
+// applying edits to its files. The files named after the entity or its
+// package are renamed.
+func (c *ProjectConfig) moveEntityPackage(oldDir, newDir string, from, to entityNames, edits map[string][]analyzer.TextEdit, recorded map[string]HexagoGeneratedFile) error {
+	fileNames := map[string]string{
+		from.Package + ".go":                      to.Package + ".go",
+		from.Package + "_test.go":                 to.Package + "_test.go",
+		utils.ToSnakeCase(from.Type) + ".go":      utils.ToSnakeCase(to.Type) + ".go",
+		utils.ToSnakeCase(from.Type) + "_test.go": utils.ToSnakeCase(to.Type) + "_test.go",
+	}
+	return c.moveDir(oldDir, newDir, fileNames, edits, recorded)
+}
+
+// moveDir moves the files of oldDir to newDir, applying edits to them.
+// fileNames renames files, by path relative to oldDir.
+func (c *ProjectConfig) moveDir(oldDir, newDir string, fileNames map[string]string, edits map[string][]analyzer.TextEdit, recorded map[string]HexagoGeneratedFile) error {
+	var files, subdirs []string
+	err := fsys.WalkDir(c.filesystem(), oldDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			subdirs = append(subdirs, path)
+		} else {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if oldDir != newDir {
+		c.progressf("📁 Moving %s to %s\n", oldDir, newDir)
+	}
+	for _, path := range files {
+		old, content, err := c.editFile(path, edits[path])
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(oldDir, path)
+		if name, ok := fileNames[rel]; ok {
+			rel = name
+		}
+		target := filepath.Join(newDir, rel)
+
+		if target != path || string(content) != string(old) {
+			c.progressf("📝 Writing %s\n", target)
+		}
+		if err := c.writeFile(target, content); err != nil {
+			return err
+		}
+		if target != path {
+			if err := c.removeFile(path); err != nil {
+				return err
+			}
+		}
+		c.moveRecord(recorded, path, target, old, content)
+	}
+
+	if oldDir == newDir {
+		return nil
+	}
+
+	// Walked parents first: remove them last
+	slices.Reverse(subdirs)
+	for _, dir := range subdirs {
+		if err := c.removeFile(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// editFiles applies edits to the files they key, except the ones skip
+// reports, and moves their manifest entries along
+func (c *ProjectConfig) editFiles(edits map[string][]analyzer.TextEdit, recorded map[string]HexagoGeneratedFile, skip func(path string) bool) error {
+	for _, path := range slices.Sorted(maps.Keys(edits)) {
+		if skip(path) {
+			continue
+		}
+		old, content, err := c.editFile(path, edits[path])
+		if err != nil {
+			return err
+		}
+		c.progressf("📝 Updating %s\n", path)
+		if err := c.writeFile(path, content); err != nil {
+			return err
+		}
+		c.moveRecord(recorded, path, path, old, content)
+	}
+	return nil
+}
+
+// editFile returns the content of path before and after applying edits.
+// Files that were gofmt-formatted are formatted again.
+func (c *ProjectConfig) editFile(path string, edits []analyzer.TextEdit) (old, content []byte, err error) {
+	old, err = c.readFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if len(edits) == 0 {
+		return old, old, nil
+	}
+
+	content = analyzer.ApplyEdits(old, edits)
+	if formatted, err := format.Source(old); err == nil && string(formatted) == string(old) {
+		if formatted, err := format.Source(content); err == nil {
+			content = formatted
+		}
+	}
+	return old, content, nil
+}
+
+// relativeEdits keys edits by path relative to the project directory,
+// dropping the files outside of it
+func (c *ProjectConfig) relativeEdits(edits map[string][]analyzer.TextEdit) (map[string][]analyzer.TextEdit, error) {
+	root, err := filepath.Abs(c.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	rel := make(map[string][]analyzer.TextEdit, len(edits))
+	for file, fileEdits := range edits {
+		if path, ok := relativePath(root, file); ok {
+			rel[path] = fileEdits
+		}
+	}
+	return rel, nil
+}
+
+// relativePath returns the path of file relative to root, and whether file
+// is inside root
+func relativePath(root, file string) (string, bool) {
+	path, err := filepath.Rel(root, file)
+	if err != nil || strings.HasPrefix(path, "..") {
+		return "", false
+	}
+	return path, true
+}
+
+// importPath returns the import path of the package in dir
+func (c *ProjectConfig) importPath(dir string) string {
+	return c.ModuleName + "/" + filepath.ToSlash(dir)
+}
+
+// inDirs reports whether path is in one of the directories keying dirs
+func inDirs(path string, dirs map[string]string) bool {
+	for dir := range dirs {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
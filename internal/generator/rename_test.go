@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestRenameIdentifier(t *testing.T) {
+	from, to := newEntityNames("Category"), newEntityNames("ProductCategory")
+
+	tests := []struct {
+		ident string
+		want  string
+	}{
+		{"Category", "ProductCategory"},
+		{"NewCategory", "NewProductCategory"},
+		{"CategoryRepository", "ProductCategoryRepository"},
+		{"CreateCategoryInput", "CreateProductCategoryInput"},
+		{"Categories", "Productcategories"},
+		{"CategoriesRepository", "ProductcategoriesRepository"},
+		{"categories", "productcategories"},
+		{"categories_test", "productcategories_test"},
+		{"categoriesDomain", "productcategoriesDomain"},
+		{"categoryResponse", "productCategoryResponse"},
+		{"toCategoryResponse", "toProductCategoryResponse"},
+		{"TestCategory_Validate", "TestProductCategory_Validate"},
+		{"Categorymap", "Categorymap"},
+		{"subcategory", "subcategory"},
+		{"Product", "Product"},
+	}
+
+	for _, tt := range tests {
+		if got := renameIdentifier(tt.ident, from, to); got != tt.want {
+			t.Errorf("renameIdentifier(%s) = %s, want %s", tt.ident, got, tt.want)
+		}
+	}
+}
+
+func TestDomainGeneratorRenameEntity(t *testing.T) {
+	dir := t.TempDir()
+	disk := fsys.NewOS(dir)
+
+	for path, content := range map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.21\n",
+		"internal/core/domain/categories/categories.go": `package categories
+
+// Category is a product category
+type Category struct {
+	Name string
+}
+
+// NewCategory creates a new Category
+func NewCategory(name string) *Category {
+	return &Category{Name: name}
+}
+`,
+		"internal/core/domain/categories/port.go": `package categories
+
+// CategoryRepository defines the secondary port for Category persistence.
+type CategoryRepository interface {
+	Save(entity *Category) error
+}
+`,
+		"internal/core/services/categories/categories.go": `package categories
+
+import categoriesDomain "example.com/demo/internal/core/domain/categories"
+
+type CategoryService struct {
+	repo categoriesDomain.CategoryRepository
+}
+
+func NewCategoryService(repo categoriesDomain.CategoryRepository) *CategoryService {
+	return &CategoryService{repo: repo}
+}
+
+func (s *CategoryService) Create(name string) error {
+	return s.repo.Save(categoriesDomain.NewCategory(name))
+}
+`,
+		"internal/core/services/services.go": `package services
+
+import (
+	categoriesSvc "example.com/demo/internal/core/services/categories"
+	categoriesDomain "example.com/demo/internal/core/domain/categories"
+)
+
+type Config struct {
+	CategoriesRepository categoriesDomain.CategoryRepository
+}
+
+type Services struct {
+	Categories *categoriesSvc.CategoryService
+}
+
+func New(config *Config) *Services {
+	return &Services{
+		Categories: categoriesSvc.NewCategoryService(config.CategoriesRepository),
+	}
+}
+`,
+		"internal/adapters/primary/http/categories/category.go": `package categories
+
+import "example.com/demo/internal/core/services"
+
+func Create(s *services.Services) error {
+	return s.Categories.Create("Category")
+}
+`,
+	} {
+		mustWrite(t, disk, path, content)
+	}
+
+	pkgs, err := analyzer.LoadSyntax(dir)
+	if err != nil {
+		t.Fatalf("LoadSyntax() error = %v", err)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.OutputDir = dir
+	config.FS = disk
+
+	gen := NewDomainGenerator(config)
+	if err := gen.RenameEntity("Category", "ProductCategory", pkgs); err != nil {
+		t.Fatalf("RenameEntity() error = %v", err)
+	}
+
+	for path, want := range map[string][]string{
+		"internal/core/domain/productcategories/productcategories.go": {
+			"package productcategories",
+			"// ProductCategory is a product category",
+			"func NewProductCategory(name string) *ProductCategory {",
+		},
+		"internal/core/domain/productcategories/port.go": {
+			"type ProductCategoryRepository interface",
+		},
+		"internal/core/services/productcategories/productcategories.go": {
+			`import productcategoriesDomain "example.com/demo/internal/core/domain/productcategories"`,
+			"productcategoriesDomain.NewProductCategory(name)",
+		},
+		"internal/core/services/services.go": {
+			"Productcategories: productcategoriesSvc.NewProductCategoryService(config.ProductcategoriesRepository)",
+		},
+		"internal/adapters/primary/http/productcategories/product_category.go": {
+			"package productcategories",
+			// String literals are left alone
+			`s.Productcategories.Create("Category")`,
+		},
+	} {
+		content := string(mustRead(t, disk, path))
+		for _, w := range want {
+			if !strings.Contains(content, w) {
+				t.Errorf("%s is missing %q:\n%s", path, w, content)
+			}
+		}
+	}
+
+	for _, dir := range []string{
+		"internal/core/domain/categories",
+		"internal/core/services/categories",
+		"internal/adapters/primary/http/categories",
+	} {
+		if fsys.Exists(disk, dir) {
+			t.Errorf("%s not moved", dir)
+		}
+	}
+
+	if err := gen.RenameEntity("Category", "Tag", pkgs); err == nil {
+		t.Error("expected an error for a missing entity")
+	}
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// sqlColumn maps an entity field to a table column
+type sqlColumn struct {
+	Name     string `yaml:"name"`    // column name
+	Field    string `yaml:"field"`   // entity field name
+	GoType   string `yaml:"go_type"` // field type as reported by the analyzer
+	Type     string `yaml:"type"`    // column type
+	Nullable bool   `yaml:"nullable,omitempty"`
+}
+
+// GenerateRepository generates a database adapter implementing the entity's
+// Repository port with SQL for the entity fields, and a migration creating
+// its table. dialect selects the SQL flavour (e.g. "postgres"); empty uses
+// the project's database.dialect.
+func (g *AdapterGenerator) GenerateRepository(repoName string, entity *analyzer.DomainStruct, dialect string) error {
+	return g.config.transact(func() error {
+		return g.generateRepository(repoName, entity, dialect)
+	})
+}
+
+// generateRepository does the work of GenerateRepository inside its transaction
+func (g *AdapterGenerator) generateRepository(repoName string, entity *analyzer.DomainStruct, dialectName string) error {
+	if dialectName == "" {
+		dialectName = g.config.DBDialect
+	}
+	if err := ValidateDatabase(dialectName, g.config.DBDriver); err != nil {
+		return err
+	}
+	dialect := sqlDialects[dialectName]
+
+	pkgName := utils.ToPlural(strings.ToLower(entity.Name))
+	adapterDir := filepath.Join("internal", "adapters", g.config.AdapterOutboundDir(), "database", pkgName)
+	filePath := filepath.Join(adapterDir, pkgName+".go")
+	testFilePath := filepath.Join(adapterDir, pkgName+"_test.go")
+
+	if g.config.fileExists(filePath) {
+		return fmt.Errorf("adapter file %s already exists", filePath)
+	}
+
+	schema, err := entitySchema(entity, dialect)
+	if err != nil {
+		return err
+	}
+	table, columns := schema.Table, schema.Columns
+
+	if err := g.EnsureDomainError("ErrNotFound", "entity not found"); err != nil {
+		return err
+	}
+	if err := g.config.createDir(adapterDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 Creating adapter file: %s\n", filePath)
+	data := map[string]any{
+		"ModuleName":        g.config.ModuleName,
+		"PackageName":       pkgName,
+		"RepoName":          repoName,
+		"EntityName":        entity.Name,
+		"EntityPackage":     pkgName,
+		"EntityImportAlias": pkgName + "Domain",
+		"Dialect":           dialect.Label,
+		"Driver":            g.config.DBDriver,
+		"DBType":            sqlDBType(g.config.DBDriver),
+		"Columns":           columns,
+		"Skipped":           skippedFields(entity, dialect),
+	}
+	for key, query := range repositoryQueries(table, columns, func(n int, _ string) string {
+		return dialect.Placeholder(n)
+	}) {
+		data[key] = query
+	}
+	// sqlx binds the writes by column name
+	if g.config.DBDriver == "sqlx" {
+		named := repositoryQueries(table, columns, func(_ int, column string) string {
+			return ":" + column
+		})
+		data["InsertSQL"], data["UpdateSQL"] = named["InsertSQL"], named["UpdateSQL"]
+	}
+	data["ScanArgs"] = fieldList(columns, "&e.")
+	data["InsertArgs"] = fieldList(columns, "e.")
+	// UPDATE binds the ID last
+	updateColumns := append([]sqlColumn{}, columns[1:]...)
+	data["UpdateArgs"] = fieldList(append(updateColumns, columns[0]), "e.")
+
+	if err := g.renderFile("adapter/secondary/database/repository.go.tmpl", filePath, data, true); err != nil {
+		return err
+	}
+
+	fmt.Printf("📝 Creating test file: %s\n", testFilePath)
+	if err := g.generateAdapterTestFile(testFilePath, repoName, pkgName, nil); err != nil {
+		return err
+	}
+
+	// Diffed against the snapshot, in case the table was migrated before
+	if _, _, err := NewMigrationGenerator(g.config).generateSchema("", schema); err != nil {
+		return err
+	}
+
+	if err := g.config.rewire(); err != nil {
+		return err
+	}
+	return g.config.reportRepositoryWiring(adapterDir)
+}
+
+// repositoryColumns returns the columns of an entity, ID first, and the
+// exported fields whose type has no column
+func repositoryColumns(entity *analyzer.DomainStruct, dialect *sqlDialect) ([]sqlColumn, []analyzer.FieldInfo) {
+	var columns []sqlColumn
+	var skipped []analyzer.FieldInfo
+	for _, f := range entity.Fields {
+		if !isExported(f.Name) {
+			continue
+		}
+		// add domain entity --fields id:string names the field Id
+		if strings.EqualFold(f.Name, "ID") {
+			if f.Type == "string" {
+				columns = append([]sqlColumn{{Name: "id", Field: f.Name, GoType: f.Type, Type: dialect.IDType}}, columns...)
+			}
+			continue
+		}
+		sqlType, nullable, ok := dialect.columnType(f.Type)
+		if !ok {
+			skipped = append(skipped, f)
+			continue
+		}
+		columns = append(columns, sqlColumn{Name: snakeCaseName(f.Name), Field: f.Name, GoType: f.Type, Type: sqlType, Nullable: nullable})
+	}
+	return columns, skipped
+}
+
+// skippedFields returns the exported fields of an entity without a column
+func skippedFields(entity *analyzer.DomainStruct, dialect *sqlDialect) []analyzer.FieldInfo {
+	_, skipped := repositoryColumns(entity, dialect)
+	return skipped
+}
+
+// repositoryQueries returns the CRUD statements of a table, keyed by template
+// field. bind returns the n-th parameter, bound to column.
+func repositoryQueries(table string, columns []sqlColumn, bind func(n int, column string) string) map[string]string {
+	names := make([]string, len(columns))
+	params := make([]string, len(columns))
+	sets := make([]string, 0, len(columns)-1)
+	for i, c := range columns {
+		names[i] = c.Name
+		params[i] = bind(i+1, c.Name)
+		if i > 0 {
+			sets = append(sets, fmt.Sprintf("%s = %s", c.Name, bind(i, c.Name)))
+		}
+	}
+	list := strings.Join(names, ", ")
+
+	orderBy := "id"
+	for _, c := range columns {
+		if c.Name == "created_at" {
+			orderBy = "created_at, id"
+		}
+	}
+
+	return map[string]string{
+		"InsertSQL": fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, list, strings.Join(params, ", ")),
+		"SelectSQL": fmt.Sprintf("SELECT %s FROM %s WHERE id = %s", list, table, bind(1, "id")),
+		"ListSQL":   fmt.Sprintf("SELECT %s FROM %s ORDER BY %s", list, table, orderBy),
+		"UpdateSQL": fmt.Sprintf("UPDATE %s SET %s WHERE id = %s", table, strings.Join(sets, ", "), bind(len(columns), "id")),
+		"DeleteSQL": fmt.Sprintf("DELETE FROM %s WHERE id = %s", table, bind(1, "id")),
+	}
+}
+
+// createTableSQL returns the up and down statements of a table migration
+func createTableSQL(table string, columns []sqlColumn) (string, string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n", table)
+	for i, c := range columns {
+		def := c.Type
+		switch {
+		case i == 0:
+			def += " PRIMARY KEY"
+		case !c.Nullable:
+			def += " NOT NULL"
+		}
+		sep := ","
+		if i == len(columns)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&b, "    %s %s%s\n", c.Name, def, sep)
+	}
+	b.WriteString(");")
+
+	return b.String(), fmt.Sprintf("DROP TABLE IF EXISTS %s;", table)
+}
+
+// fieldList joins the entity fields of columns, each with prefix
+func fieldList(columns []sqlColumn, prefix string) string {
+	args := make([]string, len(columns))
+	for i, c := range columns {
+		args[i] = prefix + c.Field
+	}
+	return strings.Join(args, ", ")
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestAdapterGeneratorGenerateRepository(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("shop", "example.com/shop")
+	config.FS = mem
+
+	entity := &analyzer.DomainStruct{
+		Name:    "OrderItem",
+		Package: "orderitems",
+		Fields: []analyzer.FieldInfo{
+			{Name: "Name", Type: "string"},
+			{Name: "ID", Type: "string"},
+			{Name: "Price", Type: "float64"},
+			{Name: "Note", Type: "*string"},
+			{Name: "Tags", Type: "[]string"},
+			{Name: "CreatedAt", Type: "Time"},
+			{Name: "internal", Type: "int"},
+		},
+	}
+
+	gen := NewAdapterGenerator(config)
+	if err := gen.GenerateRepository("OrderItemRepository", entity, "postgres"); err != nil {
+		t.Fatalf("GenerateRepository() error = %v", err)
+	}
+
+	files := map[string][]string{
+		"internal/adapters/secondary/database/orderitems/orderitems.go": {
+			"INSERT INTO order_items (id, name, price, note, created_at) VALUES ($1, $2, $3, $4, $5)",
+			"SELECT id, name, price, note, created_at FROM order_items WHERE id = $1",
+			"ORDER BY created_at, id",
+			"UPDATE order_items SET name = $1, price = $2, note = $3, created_at = $4 WHERE id = $5",
+			"e.Name, e.Price, e.Note, e.CreatedAt, e.ID",
+			"DELETE FROM order_items WHERE id = $1",
+			"s.Scan(&e.ID, &e.Name, &e.Price, &e.Note, &e.CreatedAt)",
+			"// TODO: map Tags ([]string)",
+			"return nil, domain.ErrNotFound",
+		},
+		"migrations/000001_create_order_items_table.up.sql": {
+			"CREATE TABLE IF NOT EXISTS order_items (",
+			"    id TEXT PRIMARY KEY,",
+			"    price DOUBLE PRECISION NOT NULL,",
+			"    note TEXT,",
+			"    created_at TIMESTAMPTZ NOT NULL\n);",
+		},
+		"migrations/000001_create_order_items_table.down.sql": {"DROP TABLE IF EXISTS order_items;"},
+		"internal/core/domain/errors.go":                      {"ErrNotFound"},
+	}
+	for path, contents := range files {
+		content, err := mem.ReadFile(path)
+		if err != nil {
+			t.Errorf("expected %s to be generated", path)
+			continue
+		}
+		for _, s := range contents {
+			if !strings.Contains(string(content), s) {
+				t.Errorf("%s does not contain %q\n%s", path, s, content)
+			}
+		}
+	}
+
+	noID := &analyzer.DomainStruct{Name: "Tag", Fields: []analyzer.FieldInfo{{Name: "Name", Type: "string"}}}
+	if err := gen.GenerateRepository("TagRepository", noID, "postgres"); err == nil {
+		t.Error("expected an error for an entity without an ID field")
+	}
+	if err := gen.GenerateRepository("TagRepository", entity, "oracle"); err == nil {
+		t.Error("expected an error for an unsupported dialect")
+	}
+}
+
+func TestAdapterGeneratorGenerateRepositoryDrivers(t *testing.T) {
+	entity := &analyzer.DomainStruct{
+		Name: "Order",
+		Fields: []analyzer.FieldInfo{
+			{Name: "ID", Type: "string"},
+			{Name: "Total", Type: "float64"},
+			{Name: "PlacedAt", Type: "Time"},
+		},
+	}
+
+	tests := []struct {
+		dialect   string
+		driver    string
+		adapter   []string
+		migration []string
+		migrator  []string
+	}{
+		{
+			dialect:   "mysql",
+			driver:    "database/sql",
+			adapter:   []string{"db *sql.DB", "VALUES (?, ?, ?)", "SET total = ?, placed_at = ? WHERE id = ?", "using MySQL"},
+			migration: []string{"id VARCHAR(64) PRIMARY KEY", "placed_at DATETIME(6) NOT NULL"},
+			migrator:  []string{`migratedb "github.com/golang-migrate/migrate/v4/database/mysql"`, `"mysql",`},
+		},
+		{
+			dialect:   "sqlite",
+			driver:    "sqlx",
+			adapter:   []string{"db *sqlx.DB", "VALUES (:id, :total, :placed_at)", "SET total = :total, placed_at = :placed_at WHERE id = :id", "WHERE id = ?", "NamedExecContext", `"placed_at": e.PlacedAt,`},
+			migration: []string{"total REAL NOT NULL", "placed_at DATETIME NOT NULL"},
+			migrator:  []string{"DB     *sqlx.DB", "db := m.db.DB", "database/sqlite"},
+		},
+		{
+			dialect:   "postgres",
+			driver:    "pgx",
+			adapter:   []string{"db *pgxpool.Pool", "r.db.Exec(ctx,", "errors.Is(err, pgx.ErrNoRows)", "tag.RowsAffected() == 0"},
+			migration: []string{"placed_at TIMESTAMPTZ NOT NULL"},
+			migrator:  []string{"DB     *pgxpool.Pool", "stdlib.OpenDBFromPool(m.db)", "database/pgx/v5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+"/"+tt.driver, func(t *testing.T) {
+			mem := fsys.NewMem()
+			config := NewProjectConfig("shop", "example.com/shop")
+			config.FS = mem
+			config.DBDialect = tt.dialect
+			config.DBDriver = tt.driver
+
+			if err := NewAdapterGenerator(config).GenerateRepository("OrderRepository", entity, ""); err != nil {
+				t.Fatalf("GenerateRepository() error = %v", err)
+			}
+
+			files := map[string][]string{
+				"internal/adapters/secondary/database/orders/orders.go": tt.adapter,
+				"migrations/000001_create_orders_table.up.sql":          tt.migration,
+				"internal/infrastructure/database/migrator.go":          tt.migrator,
+			}
+			for path, contents := range files {
+				content, err := mem.ReadFile(path)
+				if err != nil {
+					t.Errorf("expected %s to be generated", path)
+					continue
+				}
+				for _, s := range contents {
+					if !strings.Contains(string(content), s) {
+						t.Errorf("%s does not contain %q\n%s", path, s, content)
+					}
+				}
+			}
+		})
+	}
+
+	config := NewProjectConfig("shop", "example.com/shop")
+	config.FS = fsys.NewMem()
+	config.DBDriver = "pgx"
+	if err := NewAdapterGenerator(config).GenerateRepository("OrderRepository", entity, "mysql"); err == nil {
+		t.Error("expected an error for the pgx driver with the mysql dialect")
+	}
+}
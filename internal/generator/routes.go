@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"sort"
+)
+
+// routesRegions are the regions of routes.go regenerated by hexago, each
+// delimited by "// hexago:<region>:begin" and "// hexago:<region>:end"
+var routesRegions = []string{"routes:imports", "routes"}
+
+// routesData is the data of the routes templates
+type routesData struct {
+	*ProjectConfig
+	Handlers []string // entity handler sub-packages, mounted in routes.go
+}
+
+// routesTemplate returns the routes template of the project framework
+func (c *ProjectConfig) routesTemplate() templateItem {
+	framework := c.Framework
+	if framework == "" {
+		framework = "stdlib"
+	}
+
+	return templateItem{
+		source: fmt.Sprintf("adapter/primary/http/%s/http_routes.go.tmpl", framework),
+		target: filepath.Join("internal", "adapters", c.AdapterInboundDir(), "http", "routes.go"),
+	}
+}
+
+// renderRoutes renders routes.go mounting the given handler sub-packages
+func (c *ProjectConfig) renderRoutes(handlers []string) ([]byte, error) {
+	templ := c.routesTemplate()
+	content, err := c.templateLoader.Render(templ.source, routesData{ProjectConfig: c, Handlers: handlers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s template: %w", templ.source, err)
+	}
+	if formatted, err := format.Source(content); err == nil {
+		content = formatted
+	}
+	return content, nil
+}
+
+// upsertRoutes regenerates the hexago:routes regions of routes.go from the
+// entity handler sub-packages (those with a handlers.go) of the HTTP
+// adapter. Code outside the regions is left alone.
+func (g *AdapterGenerator) upsertRoutes() error {
+	if !g.config.IsHTTPServer() {
+		return nil
+	}
+
+	httpDir := filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "http")
+	entries, err := g.config.readDirNames(httpDir)
+	if err != nil {
+		return fmt.Errorf("reading HTTP adapter dir: %w", err)
+	}
+
+	var handlers []string
+	for _, name := range entries {
+		if g.config.fileExists(filepath.Join(httpDir, name, "handlers.go")) {
+			handlers = append(handlers, name)
+		}
+	}
+	sort.Strings(handlers)
+
+	fresh, err := g.config.renderRoutes(handlers)
+	if err != nil {
+		return err
+	}
+
+	templ := g.config.routesTemplate()
+	if !g.config.fileExists(templ.target) {
+		fmt.Printf("📝 Creating routes file: %s\n", templ.target)
+		if err := g.config.writeGenerated(templ.target, templ.source, fresh); err != nil {
+			return err
+		}
+
+		// Projects generated before routes.go do not call registerRoutes
+		adapterFile := filepath.Join(httpDir, "http.go")
+		if content, err := g.config.readFile(adapterFile); err == nil && !bytes.Contains(content, []byte("registerRoutes(")) {
+			fmt.Printf("⚠️  Warning: %s does not call registerRoutes; run hexago upgrade or call it from New\n", adapterFile)
+		}
+		return nil
+	}
+
+	old, err := g.config.readFile(templ.target)
+	if err != nil {
+		return err
+	}
+	content := old
+	for _, region := range routesRegions {
+		var ok bool
+		if content, ok = replaceRegion(content, fresh, region); !ok {
+			fmt.Printf("⚠️  Warning: %s has no hexago:%s region; mount the handlers yourself\n", templ.target, region)
+			return nil
+		}
+	}
+	if formatted, err := format.Source(content); err == nil {
+		content = formatted
+	}
+	if bytes.Equal(content, old) {
+		return nil
+	}
+
+	fmt.Printf("📝 Updating routes: %s\n", templ.target)
+	if err := g.config.writeFile(templ.target, content); err != nil {
+		return err
+	}
+	g.config.moveRecord(g.config.recordedFiles(), templ.target, templ.target, old, content)
+	return nil
+}
+
+// replaceRegion replaces the lines between the markers of a hexago region
+// in content with the ones between the same markers in fresh. ok is false
+// when either has no such region.
+func replaceRegion(content, fresh []byte, region string) ([]byte, bool) {
+	start, end, ok := regionBounds(content, region)
+	if !ok {
+		return content, false
+	}
+	freshStart, freshEnd, ok := regionBounds(fresh, region)
+	if !ok {
+		return content, false
+	}
+
+	var b bytes.Buffer
+	b.Write(content[:start])
+	b.Write(fresh[freshStart:freshEnd])
+	b.Write(content[end:])
+	return b.Bytes(), true
+}
+
+// regionBounds returns the offsets of the lines between the begin and end
+// markers of a hexago region
+func regionBounds(content []byte, region string) (start, end int, ok bool) {
+	begin := bytes.Index(content, []byte("// hexago:"+region+":begin"))
+	if begin < 0 {
+		return 0, 0, false
+	}
+	start = bytes.IndexByte(content[begin:], '\n')
+	if start < 0 {
+		return 0, 0, false
+	}
+	start += begin + 1
+
+	stop := bytes.Index(content[start:], []byte("// hexago:"+region+":end"))
+	if stop < 0 {
+		return 0, 0, false
+	}
+	end = bytes.LastIndexByte(content[:start+stop], '\n') + 1
+	return start, end, true
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestAdapterGeneratorRoutes(t *testing.T) {
+	tests := []struct {
+		framework string
+		mount     string
+	}{
+		{"chi", "Router:   r,"},
+		{"echo", "Group:    v1,"},
+		{"gin", "Group:    v1,"},
+		{"fiber", "Group:    v1,"},
+		{"stdlib", "Mux:      apiMux,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			mem := fsys.NewMem()
+			config := NewProjectConfig("demo", "example.com/demo")
+			config.FS = mem
+			config.InPlace = true
+			config.Framework = tt.framework
+
+			gen := NewProjectGenerator(config)
+			gen.projectPath = "."
+			if err := config.transact(gen.generateProject); err != nil {
+				t.Fatalf("generateProject() error = %v", err)
+			}
+
+			httpDir := "internal/adapters/primary/http/"
+			routesFile := httpDir + "routes.go"
+			if got := string(mustRead(t, mem, httpDir+"http.go")); !strings.Contains(got, "registerRoutes(srv, ") {
+				t.Errorf("http.go does not call registerRoutes:\n%s", got)
+			}
+
+			// User routes outside the regions are kept
+			userRoute := "\tsrv.Use(custom.New())\n}\n"
+			routes := strings.Replace(string(mustRead(t, mem, routesFile)), "// hexago:routes:end\n}\n", "// hexago:routes:end\n"+userRoute, 1)
+			mustWrite(t, mem, routesFile, routes)
+
+			adapters := NewAdapterGenerator(config)
+			for _, entity := range []string{"Product", "Category"} {
+				if err := adapters.GeneratePrimary("http", entity, entity, ""); err != nil {
+					t.Fatalf("GeneratePrimary(%s) error = %v", entity, err)
+				}
+			}
+
+			for _, file := range []string{routesFile, httpDir + "categories/category.go", httpDir + "categories/handlers.go"} {
+				if _, err := format.Source(mustRead(t, mem, file)); err != nil {
+					t.Errorf("%s is not valid Go: %v", file, err)
+				}
+			}
+
+			got := string(mustRead(t, mem, routesFile))
+			for _, want := range []string{
+				`"example.com/demo/internal/adapters/primary/http/categories"`,
+				"srv.Use(categories.New(&categories.Config{",
+				"srv.Use(products.New(&products.Config{",
+				tt.mount,
+				userRoute,
+			} {
+				if !strings.Contains(got, want) {
+					t.Errorf("routes.go is missing %q:\n%s", want, got)
+				}
+			}
+			if strings.Index(got, "categories.New") > strings.Index(got, "products.New") {
+				t.Errorf("handlers not mounted in order:\n%s", got)
+			}
+
+			if err := adapters.RemovePrimary("http", "Product", nil); err != nil {
+				t.Fatalf("RemovePrimary() error = %v", err)
+			}
+			got = string(mustRead(t, mem, routesFile))
+			if strings.Contains(got, "products") || !strings.Contains(got, userRoute) {
+				t.Errorf("routes.go not regenerated:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestReplaceRegion(t *testing.T) {
+	content := "a\n\t// hexago:routes:begin\n\told\n\t// hexago:routes:end\nb\n"
+	fresh := "x\n\t// hexago:routes:begin\n\tnew1\n\tnew2\n\t// hexago:routes:end\ny\n"
+
+	got, ok := replaceRegion([]byte(content), []byte(fresh), "routes")
+	want := "a\n\t// hexago:routes:begin\n\tnew1\n\tnew2\n\t// hexago:routes:end\nb\n"
+	if !ok || string(got) != want {
+		t.Errorf("replaceRegion() = %q, %v, want %q", got, ok, want)
+	}
+
+	if _, ok := replaceRegion([]byte("a\nb\n"), []byte(fresh), "routes"); ok {
+		t.Error("expected no region")
+	}
+}
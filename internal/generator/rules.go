@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// infrastructurePackages are the standard library packages the core
+// reaches its infrastructure through, which belong in adapters
+var infrastructurePackages = []string{"database/sql", "net/http", "os/exec"}
+
+// defaultArchitecture returns the layers of a hexago project and the rules
+// hexago validate checks when .hexago.yaml declares none
+func (c *ProjectConfig) defaultArchitecture() HexagoArchitectureConfig {
+	return HexagoArchitectureConfig{
+		Layers: []HexagoLayer{
+			{Name: "domain", Paths: []string{"internal/core/domain"}},
+			{Name: "ports", Paths: []string{"internal/core/ports"}},
+			{Name: "core", Paths: []string{"internal/core/" + c.CoreLogicDir()}},
+			{Name: "inbound", Paths: []string{"internal/adapters/" + c.AdapterInboundDir()}},
+			{Name: "outbound", Paths: []string{"internal/adapters/" + c.AdapterOutboundDir()}},
+			{Name: "infrastructure", Paths: []string{
+				"cmd",
+				"internal/app",
+				"internal/config",
+				"internal/infrastructure",
+				"internal/observability",
+				"internal/workers",
+			}},
+			{Name: "pkg", Paths: []string{"pkg"}},
+		},
+		Rules: []HexagoRule{
+			{
+				ID:          "domain-isolation",
+				Description: "Core domain has no external dependencies",
+				Layer:       "domain",
+				MayImport:   []string{"pkg"},
+			},
+			{
+				ID:          "ports-isolation",
+				Description: "Ports only depend on the domain",
+				Layer:       "ports",
+				MayImport:   []string{"domain", "pkg"},
+			},
+			{
+				ID:            "domain-external",
+				Description:   "Core domain only uses the standard library",
+				Layer:         "domain",
+				AllowExternal: []string{"std"},
+				DenyExternal:  infrastructurePackages,
+			},
+			{
+				ID:            "ports-external",
+				Description:   "Ports only use the standard library",
+				Layer:         "ports",
+				AllowExternal: []string{"std"},
+				DenyExternal:  infrastructurePackages,
+			},
+			{
+				ID:           "core-dependencies",
+				Description:  fmt.Sprintf("%s only depend on domain and ports", utils.ToTitleCase(c.CoreLogicDir())),
+				Layer:        "core",
+				MayNotImport: []string{"inbound", "outbound"},
+			},
+			{
+				ID:           "core-external",
+				Description:  fmt.Sprintf("%s don't use infrastructure packages", utils.ToTitleCase(c.CoreLogicDir())),
+				Layer:        "core",
+				DenyExternal: infrastructurePackages,
+				Severity:     "warning",
+			},
+			{
+				ID:           "inbound-adapters",
+				Description:  "Inbound adapters don't import outbound adapters",
+				Layer:        "inbound",
+				MayNotImport: []string{"outbound"},
+				Severity:     "warning",
+			},
+			{
+				ID:           "outbound-adapters",
+				Description:  "Outbound adapters don't import inbound adapters",
+				Layer:        "outbound",
+				MayNotImport: []string{"inbound"},
+				Severity:     "warning",
+			},
+		},
+	}
+}
+
+// architecture returns the layers and rules declared in .hexago.yaml, the
+// defaults for whichever is not declared
+func (c *ProjectConfig) architecture() (HexagoArchitectureConfig, error) {
+	arch := c.defaultArchitecture()
+	if !c.fileExists(HexagoConfigFile) {
+		return arch, nil
+	}
+
+	content, err := c.readFile(HexagoConfigFile)
+	if err != nil {
+		return arch, fmt.Errorf("read %s: %w", HexagoConfigFile, err)
+	}
+	hexCfg, err := parseHexagoConfig(content)
+	if err != nil {
+		return arch, err
+	}
+
+	if len(hexCfg.Architecture.Layers) > 0 {
+		arch.Layers = hexCfg.Architecture.Layers
+	}
+	if len(hexCfg.Architecture.Rules) > 0 {
+		arch.Rules = hexCfg.Architecture.Rules
+	}
+	return arch, arch.check()
+}
+
+// check reports rules naming unknown layers and malformed declarations
+func (a HexagoArchitectureConfig) check() error {
+	layers := make(map[string]bool)
+	for _, layer := range a.Layers {
+		if layer.Name == "" || len(layer.Paths) == 0 {
+			return fmt.Errorf("layer %q needs a name and paths", layer.Name)
+		}
+		if layers[layer.Name] {
+			return fmt.Errorf("layer %q declared twice", layer.Name)
+		}
+		layers[layer.Name] = true
+	}
+
+	ids := make(map[string]bool)
+	for _, rule := range a.Rules {
+		if rule.ID == "" {
+			return fmt.Errorf("rule of layer %q has no id", rule.Layer)
+		}
+		if ids[rule.ID] {
+			return fmt.Errorf("rule %q declared twice", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		if len(rule.MayImport) > 0 && len(rule.MayNotImport) > 0 {
+			return fmt.Errorf("rule %q needs either may_import or may_not_import, not both", rule.ID)
+		}
+		if len(rule.MayImport)+len(rule.MayNotImport)+len(rule.AllowExternal)+len(rule.DenyExternal) == 0 {
+			return fmt.Errorf("rule %q needs may_import, may_not_import, allow_external or deny_external", rule.ID)
+		}
+		for _, pattern := range slices.Concat(rule.AllowExternal, rule.DenyExternal) {
+			if pattern == "" || strings.HasSuffix(pattern, "/") {
+				return fmt.Errorf("rule %q: invalid package pattern %q", rule.ID, pattern)
+			}
+		}
+		if rule.Severity != "" && rule.Severity != "error" && rule.Severity != "warning" {
+			return fmt.Errorf("rule %q: severity must be error or warning, got %q", rule.ID, rule.Severity)
+		}
+		for _, name := range slices.Concat([]string{rule.Layer}, rule.MayImport, rule.MayNotImport) {
+			if !layers[name] {
+				return fmt.Errorf("rule %q: unknown layer %q", rule.ID, name)
+			}
+		}
+	}
+	return nil
+}
+
+// layerOf returns the layer of the package in dir, relative to the project
+// root, or "" when it is in no layer
+func (a HexagoArchitectureConfig) layerOf(dir string) string {
+	dir = path.Clean(dir)
+
+	var layer string
+	var longest int
+	for _, l := range a.Layers {
+		for _, p := range l.Paths {
+			p = path.Clean(p)
+			if (dir == p || strings.HasPrefix(dir, p+"/")) && len(p) > longest {
+				layer, longest = l.Name, len(p)
+			}
+		}
+	}
+	return layer
+}
+
+// allows reports whether the layer of the rule may import the layer to
+func (r HexagoRule) allows(to string) bool {
+	if to == "" || to == r.Layer {
+		return true
+	}
+	if len(r.MayImport) > 0 {
+		return slices.Contains(r.MayImport, to)
+	}
+	return !slices.Contains(r.MayNotImport, to)
+}
+
+// allowsExternal reports whether the layer of the rule may import the
+// package importPath of another module or of the standard library, whose
+// packages std holds
+func (r HexagoRule) allowsExternal(importPath string, std map[string]bool) bool {
+	matches := func(pattern string) bool { return matchesPackage(pattern, importPath, std) }
+	if len(r.AllowExternal) > 0 && !slices.ContainsFunc(r.AllowExternal, matches) {
+		return false
+	}
+	return !slices.ContainsFunc(r.DenyExternal, matches)
+}
+
+// matchesPackage reports whether the package pattern of a rule matches
+// importPath: "std" the standard library, whose packages std holds, any
+// other pattern the package of that path and the ones below it
+func matchesPackage(pattern, importPath string, std map[string]bool) bool {
+	if pattern == "std" {
+		return std[importPath]
+	}
+	return withinPath(importPath, pattern)
+}
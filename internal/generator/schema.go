@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaDir holds the table snapshots migrations are diffed against
+var schemaDir = filepath.Join(".hexago", "schema")
+
+// tableSchema is the snapshot of a table as of its last migration
+type tableSchema struct {
+	Table   string      `yaml:"table"`
+	Entity  string      `yaml:"entity"`
+	Dialect string      `yaml:"dialect"`
+	Columns []sqlColumn `yaml:"columns"`
+}
+
+// GenerateFromEntity creates a migration bringing the entity's table from its
+// snapshot in .hexago/schema/ to the current entity fields: CREATE TABLE when
+// there is no snapshot yet, ALTER TABLE ADD/DROP COLUMN otherwise. An empty
+// migrationName is derived from the table. It returns the migration number
+// and name, and fails when the table has not changed.
+func (g *MigrationGenerator) GenerateFromEntity(migrationName string, entity *analyzer.DomainStruct) (int, string, error) {
+	var migrationNumber int
+	err := g.config.transact(func() error {
+		var err error
+		migrationNumber, migrationName, err = g.generateFromEntity(migrationName, entity)
+		return err
+	})
+	return migrationNumber, migrationName, err
+}
+
+// generateFromEntity does the work of GenerateFromEntity inside its transaction
+func (g *MigrationGenerator) generateFromEntity(migrationName string, entity *analyzer.DomainStruct) (int, string, error) {
+	next, err := entitySchema(entity, g.config.dialect())
+	if err != nil {
+		return 0, "", err
+	}
+
+	migrationNumber, migrationName, err := g.generateSchema(migrationName, next)
+	if err == nil && migrationNumber == 0 {
+		err = fmt.Errorf("table %s is up to date with entity %s", next.Table, entity.Name)
+	}
+	return migrationNumber, migrationName, err
+}
+
+// generateSchema creates the migration from the snapshot of next.Table to next
+// and saves next as the new snapshot. It returns 0 when nothing changed.
+func (g *MigrationGenerator) generateSchema(migrationName string, next *tableSchema) (int, string, error) {
+	prev, err := g.loadSchema(next.Table)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var up, down string
+	if prev == nil {
+		up, down = createTableSQL(next.Table, next.Columns)
+	} else {
+		up, down = alterTableSQL(prev, next, g.config.dialect())
+	}
+	if up == "" {
+		return 0, migrationName, nil
+	}
+
+	if migrationName == "" {
+		verb := "create"
+		if prev != nil {
+			verb = "alter"
+		}
+		migrationName = verb + "_" + next.Table + "_table"
+	}
+
+	migrationNumber, err := g.generateSQL(migrationName, up, down)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return migrationNumber, migrationName, g.saveSchema(next)
+}
+
+// entitySchema maps an entity to its table, warning about fields without a column
+func entitySchema(entity *analyzer.DomainStruct, dialect *sqlDialect) (*tableSchema, error) {
+	columns, skipped := repositoryColumns(entity, dialect)
+	if len(columns) == 0 || columns[0].Name != "id" {
+		return nil, fmt.Errorf("entity %s has no ID field of type string", entity.Name)
+	}
+	if len(columns) == 1 {
+		return nil, fmt.Errorf("entity %s has no field to store besides ID", entity.Name)
+	}
+	for _, f := range skipped {
+		fmt.Printf("⚠️  Warning: %s.%s (%s) has no %s column type; map it by hand\n", entity.Name, f.Name, f.Type, dialect.Label)
+	}
+
+	return &tableSchema{
+		Table:   utils.ToPlural(snakeCaseName(entity.Name)),
+		Entity:  entity.Name,
+		Dialect: dialect.Name,
+		Columns: columns,
+	}, nil
+}
+
+// loadSchema reads the snapshot of table, or nil if there is none
+func (g *MigrationGenerator) loadSchema(table string) (*tableSchema, error) {
+	path := filepath.Join(schemaDir, table+".yaml")
+	if !g.config.fileExists(path) {
+		return nil, nil
+	}
+
+	content, err := g.config.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema tableSchema
+	if err := yaml.Unmarshal(content, &schema); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &schema, nil
+}
+
+// saveSchema writes the snapshot of a table
+func (g *MigrationGenerator) saveSchema(schema *tableSchema) error {
+	content, err := yaml.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("marshal %s schema: %w", schema.Table, err)
+	}
+
+	path := filepath.Join(schemaDir, schema.Table+".yaml")
+	fmt.Printf("📝 Saving schema snapshot: %s\n", path)
+	return g.config.writeFile(path, append([]byte("# Generated by hexago; do not edit.\n"), content...))
+}
+
+// alterTableSQL returns the up and down statements turning prev into next,
+// or empty strings when the columns are the same. Type changes are left as
+// TODO comments since they need a dialect-specific conversion.
+func alterTableSQL(prev, next *tableSchema, dialect *sqlDialect) (string, string) {
+	prevColumns := make(map[string]sqlColumn, len(prev.Columns))
+	for _, c := range prev.Columns {
+		prevColumns[c.Name] = c
+	}
+	nextColumns := make(map[string]sqlColumn, len(next.Columns))
+	for _, c := range next.Columns {
+		nextColumns[c.Name] = c
+	}
+
+	var up, down []string
+	addColumn := func(c sqlColumn) string {
+		return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", next.Table, c.Name, dialect.addColumnDef(c))
+	}
+	dropColumn := func(c sqlColumn) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", next.Table, c.Name)
+	}
+
+	for _, c := range next.Columns {
+		old, ok := prevColumns[c.Name]
+		switch {
+		case !ok:
+			up = append(up, addColumn(c))
+			down = append([]string{dropColumn(c)}, down...)
+		case old.Type != c.Type || old.Nullable != c.Nullable:
+			up = append(up, fmt.Sprintf("-- TODO: change %s.%s from %s to %s", next.Table, c.Name, columnDef(old), columnDef(c)))
+			down = append([]string{fmt.Sprintf("-- TODO: change %s.%s from %s to %s", next.Table, c.Name, columnDef(c), columnDef(old))}, down...)
+		}
+	}
+	for _, c := range prev.Columns {
+		if _, ok := nextColumns[c.Name]; !ok {
+			up = append(up, dropColumn(c))
+			down = append([]string{addColumn(c)}, down...)
+		}
+	}
+
+	return strings.Join(up, "\n"), strings.Join(down, "\n")
+}
+
+// columnDef returns the type and nullability of a column
+func columnDef(c sqlColumn) string {
+	if c.Nullable {
+		return c.Type
+	}
+	return c.Type + " NOT NULL"
+}
+
+// addColumnDef returns the definition of a column added to a table that may
+// already have rows: NOT NULL columns get the zero value of their field as
+// default where the dialect requires one.
+func (d *sqlDialect) addColumnDef(c sqlColumn) string {
+	def := columnDef(c)
+	if c.Nullable || !d.AddColumnDefault {
+		return def
+	}
+
+	switch c.GoType {
+	case "string", "[]byte", "[]uint8":
+		def += " DEFAULT ''"
+	case "bool":
+		def += " DEFAULT FALSE"
+	case "Time", "time.Time":
+		// a constant, SQLite rejects CURRENT_TIMESTAMP in ADD COLUMN
+		def += " DEFAULT '0001-01-01 00:00:00'"
+	default:
+		def += " DEFAULT 0"
+	}
+	return def
+}
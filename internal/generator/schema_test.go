@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestMigrationGeneratorGenerateFromEntity(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("shop", "example.com/shop")
+	config.FS = mem
+	gen := NewMigrationGenerator(config)
+
+	user := &analyzer.DomainStruct{
+		Name: "User",
+		Fields: []analyzer.FieldInfo{
+			{Name: "ID", Type: "string"},
+			{Name: "Email", Type: "string"},
+			{Name: "Nickname", Type: "*string"},
+		},
+	}
+
+	number, name, err := gen.GenerateFromEntity("", user)
+	if err != nil {
+		t.Fatalf("GenerateFromEntity() error = %v", err)
+	}
+	if number != 1 || name != "create_users_table" {
+		t.Errorf("GenerateFromEntity() = %d, %q, want 1, create_users_table", number, name)
+	}
+
+	if _, _, err := gen.GenerateFromEntity("", user); err == nil {
+		t.Error("expected an error when the table is up to date")
+	}
+
+	user.Fields = []analyzer.FieldInfo{
+		{Name: "ID", Type: "string"},
+		{Name: "Email", Type: "*string"},
+		{Name: "Active", Type: "bool"},
+		{Name: "CreatedAt", Type: "Time"},
+	}
+	number, name, err = gen.GenerateFromEntity("", user)
+	if err != nil {
+		t.Fatalf("GenerateFromEntity() error = %v", err)
+	}
+	if number != 2 || name != "alter_users_table" {
+		t.Errorf("GenerateFromEntity() = %d, %q, want 2, alter_users_table", number, name)
+	}
+
+	files := map[string][]string{
+		"migrations/000001_create_users_table.up.sql": {
+			"CREATE TABLE IF NOT EXISTS users (",
+			"    email TEXT NOT NULL,",
+			"    nickname TEXT\n);",
+		},
+		"migrations/000001_create_users_table.down.sql": {"DROP TABLE IF EXISTS users;"},
+		"migrations/000002_alter_users_table.up.sql": {
+			"-- TODO: change users.email from TEXT NOT NULL to TEXT\n" +
+				"ALTER TABLE users ADD COLUMN active BOOLEAN NOT NULL DEFAULT FALSE;\n" +
+				"ALTER TABLE users ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00';\n" +
+				"ALTER TABLE users DROP COLUMN nickname;",
+		},
+		"migrations/000002_alter_users_table.down.sql": {
+			"ALTER TABLE users ADD COLUMN nickname TEXT;\n" +
+				"ALTER TABLE users DROP COLUMN created_at;\n" +
+				"ALTER TABLE users DROP COLUMN active;\n" +
+				"-- TODO: change users.email from TEXT to TEXT NOT NULL",
+		},
+		".hexago/schema/users.yaml": {"table: users", "name: created_at", "go_type: Time"},
+	}
+	for path, contents := range files {
+		content, err := mem.ReadFile(path)
+		if err != nil {
+			t.Errorf("expected %s to be generated", path)
+			continue
+		}
+		for _, s := range contents {
+			if !strings.Contains(string(content), s) {
+				t.Errorf("%s does not contain %q\n%s", path, s, content)
+			}
+		}
+	}
+}
+
+func TestSQLDialectAddColumnDef(t *testing.T) {
+	column := sqlColumn{Name: "total", GoType: "float64", Type: "DOUBLE"}
+
+	if got := sqlDialects["mysql"].addColumnDef(column); got != "DOUBLE NOT NULL" {
+		t.Errorf("mysql addColumnDef() = %q, want DOUBLE NOT NULL", got)
+	}
+	if got := sqlDialects["sqlite"].addColumnDef(column); got != "DOUBLE NOT NULL DEFAULT 0" {
+		t.Errorf("sqlite addColumnDef() = %q, want DOUBLE NOT NULL DEFAULT 0", got)
+	}
+}
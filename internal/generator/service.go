@@ -2,7 +2,7 @@ package generator
 
 import (
 	"fmt"
-	"os"
+	"go/format"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -41,8 +41,15 @@ func NewServiceGenerator(config *ProjectConfig) *ServiceGenerator {
 // When omitted, serviceName itself is used as the package name.
 // portInfo (optional) provides method signatures for code generation.
 func (g *ServiceGenerator) Generate(serviceName, entityName, description string, portInfo *analyzer.PortInfo) error {
-	baseServiceDir := filepath.Join(g.config.OutputDir, "internal", "core", g.config.CoreLogicDir())
-	if !utils.FileExists(baseServiceDir) {
+	return g.config.transact(func() error {
+		return g.generate(serviceName, entityName, description, portInfo)
+	})
+}
+
+// generate does the work of Generate inside its transaction
+func (g *ServiceGenerator) generate(serviceName, entityName, description string, portInfo *analyzer.PortInfo) error {
+	baseServiceDir := filepath.Join("internal", "core", g.config.CoreLogicDir())
+	if !g.config.fileExists(baseServiceDir) {
 		return fmt.Errorf("directory %s does not exist. Are you in a hexagonal project?", baseServiceDir)
 	}
 
@@ -58,17 +65,17 @@ func (g *ServiceGenerator) Generate(serviceName, entityName, description string,
 	}
 
 	serviceDir := filepath.Join("internal", "core", g.config.CoreLogicDir(), pkgName)
-	if err := utils.CreateDir(serviceDir); err != nil {
+	if err := g.config.createDir(serviceDir); err != nil {
 		return fmt.Errorf("creating directory %s: %w", serviceDir, err)
 	}
 
 	fileName := pkgName + ".go"
 	testFileName := pkgName + "_test.go"
 
-	filePath := filepath.Join(g.config.OutputDir, serviceDir, fileName)
-	testFilePath := filepath.Join(g.config.OutputDir, serviceDir, testFileName)
+	filePath := filepath.Join(serviceDir, fileName)
+	testFilePath := filepath.Join(serviceDir, testFileName)
 
-	if utils.FileExists(filePath) {
+	if g.config.fileExists(filePath) {
 		return fmt.Errorf("service file %s already exists", filePath)
 	}
 
@@ -127,7 +134,7 @@ func (g *ServiceGenerator) generateServiceFile(filePath, serviceName, entityName
 		return fmt.Errorf("failed to render service template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "service/service.go.tmpl", content)
 }
 
 // generateTestFile generates the test file
@@ -149,22 +156,35 @@ func (g *ServiceGenerator) generateTestFile(filePath, serviceName, pkgName strin
 		return fmt.Errorf("failed to render service test template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "service/service_test.go.tmpl", content)
 }
 
-// upsertAggregator scans all service sub-packages and regenerates services.go.
+// upsertAggregator scans all service sub-packages and regenerates services.go,
+// then the composition root constructing them.
 func (g *ServiceGenerator) upsertAggregator(baseServiceDir string) error {
-	entries, err := os.ReadDir(baseServiceDir)
+	serviceEntries, err := g.serviceEntries(baseServiceDir)
 	if err != nil {
-		return fmt.Errorf("reading service dir: %w", err)
+		return err
+	}
+	if err := g.writeAggregator(baseServiceDir, serviceEntries); err != nil {
+		return err
+	}
+
+	return g.config.rewire()
+}
+
+// serviceEntries returns the service sub-packages of baseServiceDir
+func (g *ServiceGenerator) serviceEntries(baseServiceDir string) ([]ServiceEntry, error) {
+	entries, err := g.config.readDirNames(baseServiceDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading service dir: %w", err)
 	}
 
 	var serviceEntries []ServiceEntry
-	for _, entry := range entries {
-		if !entry.IsDir() {
+	for _, pkgName := range entries {
+		if !g.config.isDir(filepath.Join(baseServiceDir, pkgName)) {
 			continue
 		}
-		pkgName := entry.Name()
 		srcFile := filepath.Join(baseServiceDir, pkgName, pkgName+".go")
 		entityName, hasEntity, err := g.extractServiceInfo(srcFile)
 		if err != nil {
@@ -181,12 +201,28 @@ func (g *ServiceGenerator) upsertAggregator(baseServiceDir string) error {
 			HasEntity:     hasEntity,
 		})
 	}
+	return serviceEntries, nil
+}
 
+// writeAggregator writes services.go with serviceEntries
+func (g *ServiceGenerator) writeAggregator(baseServiceDir string, serviceEntries []ServiceEntry) error {
+	aggregatorPath := filepath.Join(baseServiceDir, "services.go")
 	if len(serviceEntries) == 0 {
-		return nil
+		// The last service was removed: back to the stub of hexago init
+		if !g.config.fileExists(aggregatorPath) {
+			return nil
+		}
+		content, err := g.config.templateLoader.Render("service/services_stub.go.tmpl", g.config)
+		if err != nil {
+			return fmt.Errorf("failed to render services stub template: %w", err)
+		}
+		if formatted, err := format.Source(content); err == nil {
+			content = formatted
+		}
+		fmt.Printf("📝 Updating services aggregator: %s\n", aggregatorPath)
+		return g.config.writeGenerated(aggregatorPath, "service/services_stub.go.tmpl", content)
 	}
 
-	aggregatorPath := filepath.Join(baseServiceDir, "services.go")
 	data := map[string]any{
 		"ModuleName": g.config.ModuleName,
 		"CoreLogic":  g.config.CoreLogicDir(),
@@ -199,14 +235,14 @@ func (g *ServiceGenerator) upsertAggregator(baseServiceDir string) error {
 	}
 
 	fmt.Printf("📝 Updating services aggregator: %s\n", aggregatorPath)
-	return utils.WriteFile(aggregatorPath, content)
+	return g.config.writeGenerated(aggregatorPath, "service/services_aggregator.go.tmpl", content)
 }
 
 // extractServiceInfo scans a service Go file for the first `type XxxService struct`
 // declaration and returns the entity name ("Xxx") plus whether the service is
 // entity-bound (i.e. it imports from internal/core/domain/).
 func (g *ServiceGenerator) extractServiceInfo(filePath string) (entityName string, hasEntity bool, err error) {
-	content, err := os.ReadFile(filePath)
+	content, err := g.config.readFile(filePath)
 	if err != nil {
 		return "", false, err
 	}
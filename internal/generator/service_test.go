@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestServiceGeneratorGenerate(t *testing.T) {
+	mem := fsys.NewMem()
+	if err := mem.MkdirAll("internal/core/services", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+
+	gen := NewServiceGenerator(config)
+	for _, entity := range []string{"Category", "Product"} {
+		if err := gen.Generate(entity, entity, "", nil); err != nil {
+			t.Fatalf("Generate(%s) error = %v", entity, err)
+		}
+	}
+
+	for _, path := range []string{
+		"internal/core/services/categories/categories.go",
+		"internal/core/services/categories/categories_test.go",
+		"internal/core/services/products/products.go",
+		"internal/core/services/products/products_test.go",
+	} {
+		if !fsys.Exists(mem, path) {
+			t.Errorf("expected %s to be generated", path)
+		}
+	}
+
+	aggregator, err := mem.ReadFile("internal/core/services/services.go")
+	if err != nil {
+		t.Fatalf("services aggregator not generated: %v", err)
+	}
+	for _, want := range []string{
+		`categoriesSvc "example.com/demo/internal/core/services/categories"`,
+		`productsSvc "example.com/demo/internal/core/services/products"`,
+	} {
+		if !strings.Contains(string(aggregator), want) {
+			t.Errorf("services.go is missing %s", want)
+		}
+	}
+
+	if err := gen.Generate("Category", "Category", "", nil); err == nil {
+		t.Error("expected an error when the service already exists")
+	}
+}
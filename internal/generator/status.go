@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FileState tells how a generated file compares with its manifest entry
+type FileState string
+
+const (
+	FileUntouched FileState = "untouched"
+	FileModified  FileState = "modified"
+	FileDeleted   FileState = "deleted"
+)
+
+// FileStatus is a file of the manifest and its state in the project
+type FileStatus struct {
+	HexagoGeneratedFile
+	State FileState
+}
+
+// Status compares the files in the manifest of .hexago.yaml with the
+// project, sorted by path
+func Status(config *ProjectConfig) ([]FileStatus, error) {
+	content, err := config.readFile(HexagoConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", HexagoConfigFile, err)
+	}
+	hexCfg, err := parseHexagoConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(hexCfg.Generated.Files) == 0 {
+		return nil, fmt.Errorf("%s has no manifest of generated files: the project was created before hexago recorded their hashes", HexagoConfigFile)
+	}
+
+	statuses := make([]FileStatus, 0, len(hexCfg.Generated.Files))
+	for _, f := range hexCfg.Generated.Files {
+		status := FileStatus{HexagoGeneratedFile: f, State: FileUntouched}
+		if !config.fileExists(f.Path) {
+			status.State = FileDeleted
+		} else if content, err := config.readFile(f.Path); err != nil {
+			return nil, err
+		} else if hashContent(content) != f.Hash {
+			status.State = FileModified
+		}
+		statuses = append(statuses, status)
+	}
+
+	slices.SortFunc(statuses, func(a, b FileStatus) int { return strings.Compare(a.Path, b.Path) })
+	return statuses, nil
+}
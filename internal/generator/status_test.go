@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestStatus(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	config.InPlace = true
+
+	gen := NewProjectGenerator(config)
+	gen.projectPath = "."
+	if err := config.transact(gen.generateProject); err != nil {
+		t.Fatalf("generateProject() error = %v", err)
+	}
+
+	// Files of hexago add join the manifest
+	if err := NewServiceGenerator(config).Generate("Product", "Product", "", nil); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	hexCfg, err := parseHexagoConfig(mustRead(t, mem, HexagoConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var service *HexagoGeneratedFile
+	for i, f := range hexCfg.Generated.Files {
+		if f.Path == "internal/core/services/products/products.go" {
+			service = &hexCfg.Generated.Files[i]
+		}
+	}
+	if service == nil {
+		t.Fatalf("service not recorded in the manifest: %+v", hexCfg.Generated.Files)
+	}
+	if service.Template != "service/service.go.tmpl" || !strings.HasPrefix(service.Source, "embedded") {
+		t.Errorf("service recorded as %+v", service)
+	}
+
+	mustWrite(t, mem, "Makefile", "# edited\n")
+	if err := mem.Remove("main.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := Status(config)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	want := map[string]FileState{
+		"Makefile": FileModified,
+		"main.go":  FileDeleted,
+		"internal/core/services/products/products.go": FileUntouched,
+		// Regenerated by hexago add service
+		"internal/core/services/services.go": FileUntouched,
+	}
+	for _, s := range statuses {
+		if state, ok := want[s.Path]; ok && s.State != state {
+			t.Errorf("%s is %s, want %s", s.Path, s.State, state)
+		}
+		if _, ok := want[s.Path]; !ok && s.State != FileUntouched {
+			t.Errorf("%s is %s, want %s", s.Path, s.State, FileUntouched)
+		}
+	}
+}
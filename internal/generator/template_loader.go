@@ -87,6 +87,17 @@ func NewTemplateLoader() *TemplateLoader {
 	return loader
 }
 
+// SetProjectDir resolves project-local overrides (.hexago/templates/) against
+// dir instead of the current working directory
+func (l *TemplateLoader) SetProjectDir(dir string) {
+	for i := range l.sources {
+		if l.sources[i].Name == "project-local" {
+			l.sources[i].Path = filepath.Join(dir, ".hexago", "templates")
+		}
+	}
+	l.cache = make(map[string]*template.Template)
+}
+
 // Load loads and parses a template by name
 func (l *TemplateLoader) Load(name string) (*template.Template, error) {
 	// Check cache first
@@ -2,9 +2,9 @@ package generator
 
 import (
 	"fmt"
+	"go/format"
 	"path/filepath"
-
-	"github.com/padiazg/hexago/pkg/utils"
+	"strings"
 )
 
 const (
@@ -18,6 +18,7 @@ const (
 	versionTestTemplate         string = "version-test"
 	versionCmdTemplate          string = "version-cmd"
 	processorTemplate           string = "processor"
+	jobTemplate                 string = "job"
 	configTemplate              string = "config"
 	loggerTemplate              string = "logger"
 	httpServerInterfaceTemplate string = "http-server-interface"
@@ -32,6 +33,8 @@ const (
 	healthTemplate              string = "health"
 	metricsTemplate             string = "metrics"
 	servicesStubTemplate        string = "services-stub"
+	cliAdapterTemplate          string = "cli-adapter"
+	cliHelloTemplate            string = "cli-hello"
 )
 
 type templateItem struct {
@@ -39,6 +42,12 @@ type templateItem struct {
 	target string
 }
 
+// generatedFile is the output of a project template
+type generatedFile struct {
+	templateItem
+	content []byte
+}
+
 type templateFn func(g *ProjectGenerator) templateItem
 
 var templateMap = map[string]templateFn{
@@ -62,11 +71,18 @@ var templateMap = map[string]templateFn{
 	},
 	runTemplate: func(g *ProjectGenerator) templateItem {
 		var templateName string
+		targetName := "run.go"
 		switch g.config.ProjectType {
 		case "http-server":
 			templateName = "cmd/run_http_server.go.tmpl"
 		case "service":
 			templateName = "cmd/run_service.go.tmpl"
+		case "job":
+			templateName = "cmd/run_job.go.tmpl"
+		case "cli":
+			// No run command: the adapter subcommands are the application
+			templateName = "cmd/run_cli.go.tmpl"
+			targetName = "commands.go"
 		default:
 			// Fallback to http-server for backward compatibility
 			templateName = "cmd/run.go.tmpl"
@@ -74,7 +90,7 @@ var templateMap = map[string]templateFn{
 
 		return templateItem{
 			source: templateName,
-			target: filepath.Join("cmd", "run.go"),
+			target: filepath.Join("cmd", targetName),
 		}
 	},
 	rootTemplate: func(g *ProjectGenerator) templateItem {
@@ -113,6 +129,12 @@ var templateMap = map[string]templateFn{
 			target: filepath.Join("internal", "core", g.config.CoreLogicDir(), "processor.go"),
 		}
 	},
+	jobTemplate: func(g *ProjectGenerator) templateItem {
+		return templateItem{
+			source: "service/job.go.tmpl",
+			target: filepath.Join("internal", "core", g.config.CoreLogicDir(), "job.go"),
+		}
+	},
 	configTemplate: func(g *ProjectGenerator) templateItem {
 		return templateItem{
 			source: "project/config.go.tmpl",
@@ -234,6 +256,18 @@ var templateMap = map[string]templateFn{
 			target: filepath.Join("internal", "core", g.config.CoreLogicDir(), "services.go"),
 		}
 	},
+	cliAdapterTemplate: func(g *ProjectGenerator) templateItem {
+		return templateItem{
+			source: "adapter/primary/cli/cli_adapter.go.tmpl",
+			target: filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "cli", "cli.go"),
+		}
+	},
+	cliHelloTemplate: func(g *ProjectGenerator) templateItem {
+		return templateItem{
+			source: "adapter/primary/cli/cli_hello.go.tmpl",
+			target: filepath.Join("internal", "adapters", g.config.AdapterInboundDir(), "cli", "hello.go"),
+		}
+	},
 }
 
 // generatefile generates a given file
@@ -250,5 +284,13 @@ func (g *ProjectGenerator) generateFile(name string) error {
 		return fmt.Errorf("failed to render %s template: %w", templ.source, err)
 	}
 
-	return utils.WriteFile(filepath.Join(g.projectPath, templ.target), content)
+	// Formatted here so the recorded output is what go fmt leaves on disk
+	if strings.HasSuffix(templ.target, ".go") {
+		if formatted, err := format.Source(content); err == nil {
+			content = formatted
+		}
+	}
+	g.generated = append(g.generated, generatedFile{templateItem: templ, content: content})
+
+	return g.config.writeFile(filepath.Join(g.projectPath, templ.target), content)
 }
@@ -21,9 +21,16 @@ func NewToolGenerator(config *ProjectConfig) *ToolGenerator {
 
 // Generate creates a new infrastructure tool
 func (g *ToolGenerator) Generate(toolType, toolName, description string) error {
+	return g.config.transact(func() error {
+		return g.generate(toolType, toolName, description)
+	})
+}
+
+// generate does the work of Generate inside its transaction
+func (g *ToolGenerator) generate(toolType, toolName, description string) error {
 	// Create directory
 	toolDir := filepath.Join("internal", "infrastructure", toolType)
-	if err := utils.CreateDir(toolDir); err != nil {
+	if err := g.config.createDir(toolDir); err != nil {
 		return err
 	}
 
@@ -59,7 +66,7 @@ func (g *ToolGenerator) generateLogger(dir, name, description string) error {
 		return fmt.Errorf("failed to render logger template: %w", err)
 	}
 
-	if err := utils.WriteFile(filePath, content); err != nil {
+	if err := g.config.writeGenerated(filePath, "tool/logger.go.tmpl", content); err != nil {
 		return err
 	}
 
@@ -83,7 +90,7 @@ func (g *ToolGenerator) generateValidator(dir, name, description string) error {
 		return fmt.Errorf("failed to render validator template: %w", err)
 	}
 
-	if err := utils.WriteFile(filePath, content); err != nil {
+	if err := g.config.writeGenerated(filePath, "tool/validator.go.tmpl", content); err != nil {
 		return err
 	}
 
@@ -108,7 +115,7 @@ func (g *ToolGenerator) generateMapper(dir, name, description string) error {
 		return fmt.Errorf("failed to render mapper template: %w", err)
 	}
 
-	if err := utils.WriteFile(filePath, content); err != nil {
+	if err := g.config.writeGenerated(filePath, "tool/mapper.go.tmpl", content); err != nil {
 		return err
 	}
 
@@ -133,7 +140,7 @@ func (g *ToolGenerator) generateMiddleware(dir, name, description string) error
 		return fmt.Errorf("failed to render middleware template: %w", err)
 	}
 
-	if err := utils.WriteFile(filePath, content); err != nil {
+	if err := g.config.writeGenerated(filePath, "tool/middleware.go.tmpl", content); err != nil {
 		return err
 	}
 
@@ -172,7 +179,7 @@ func (g *ToolGenerator) generateTestFile(dir, name, toolType string) error {
 		return fmt.Errorf("failed to render tool test template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, templateName, content)
 }
 
 func getDescription(desc, defaultDesc string) string {
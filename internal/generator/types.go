@@ -1,6 +1,10 @@
 package generator
 
-import "time"
+import (
+	"time"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
 
 // ProjectConfig holds the configuration for generating a new project
 type ProjectConfig struct {
@@ -15,6 +19,14 @@ type ProjectConfig struct {
 	AdapterStyle string // "primary-secondary" or "driver-driven"
 	CoreLogic    string // "services" or "usecases"
 
+	// SQL database used by database adapters and migrations
+	DBDialect string // "postgres", "mysql", "sqlite"
+	DBDriver  string // "database/sql", "pgx", "sqlx"
+
+	// MigrationVersioning numbers new migrations: "sequential" (000001) or
+	// "timestamp" (YYYYMMDDHHMMSS), which keeps parallel branches from colliding
+	MigrationVersioning string
+
 	// Metadata
 	Year      int
 	Author    string
@@ -30,30 +42,46 @@ type ProjectConfig struct {
 	WithObservability bool
 	InPlace           bool // Generate directly in OutputDir (no <ProjectName> subdirectory)
 
+	// FS is the filesystem generators work on; defaults to the OS filesystem
+	// rooted at OutputDir
+	FS fsys.FS
+
+	// DryRun stages every write in memory instead of touching the disk
+	DryRun bool
+
 	templateLoader *TemplateLoader
+	overlay        *fsys.Overlay
+	tx             *fsys.Tx
+	staging        bool                  // inside the outermost transact
+	quiet          bool                  // progress lines are left out, e.g. while previewing a fix
+	manifest       []HexagoGeneratedFile // files rendered in this transaction
+	unrecorded     []string              // files removed in this transaction
 }
 
 // NewProjectConfig creates a new ProjectConfig with sensible defaults
 func NewProjectConfig(projectName, moduleName string) *ProjectConfig {
 	return &ProjectConfig{
-		ProjectName:       projectName,
-		ModuleName:        moduleName,
-		OutputDir:         ".",
-		ProjectType:       "http-server", // Default for backward compatibility
-		Framework:         "stdlib",
-		AdapterStyle:      "primary-secondary",
-		CoreLogic:         "services",
-		WithDocker:        false,
-		WithExample:       false,
-		WithMigrations:    false,
-		WithMetrics:       false,
-		ExplicitPorts:     false,
-		WithWorkers:       false,
-		WithObservability: false,
-		GoVersion:         "1.21",
-		Author:            "",
-		Year:              time.Now().Year(),
-		templateLoader:    NewTemplateLoader(),
+		ProjectName:         projectName,
+		ModuleName:          moduleName,
+		OutputDir:           ".",
+		ProjectType:         "http-server", // Default for backward compatibility
+		Framework:           "stdlib",
+		AdapterStyle:        "primary-secondary",
+		CoreLogic:           "services",
+		DBDialect:           "postgres",
+		DBDriver:            "database/sql",
+		MigrationVersioning: "sequential",
+		WithDocker:          false,
+		WithExample:         false,
+		WithMigrations:      false,
+		WithMetrics:         false,
+		ExplicitPorts:       false,
+		WithWorkers:         false,
+		WithObservability:   false,
+		GoVersion:           "1.21",
+		Author:              "",
+		Year:                time.Now().Year(),
+		templateLoader:      NewTemplateLoader(),
 	}
 }
 
@@ -88,6 +116,16 @@ func (c *ProjectConfig) IsService() bool {
 	return c.ProjectType == "service"
 }
 
+// IsCLI returns true if project is a command-line application
+func (c *ProjectConfig) IsCLI() bool {
+	return c.ProjectType == "cli"
+}
+
+// IsJob returns true if project is a run-to-completion job
+func (c *ProjectConfig) IsJob() bool {
+	return c.ProjectType == "job"
+}
+
 // NeedsWebFramework returns true if project needs a web framework for main logic
 func (c *ProjectConfig) NeedsWebFramework() bool {
 	return c.IsHTTPServer()
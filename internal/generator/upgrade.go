@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+	"github.com/padiazg/hexago/pkg/utils"
+	"github.com/padiazg/hexago/pkg/version"
+)
+
+// baseDir holds the generated content of the project files, the common
+// ancestor hexago upgrade merges against
+var baseDir = filepath.Join(".hexago", "base")
+
+// UpgradeResult lists what Upgrade did with each project file
+type UpgradeResult struct {
+	FromVersion string   // hexago version that generated the project
+	Updated     []string // unmodified files replaced by the new output
+	Merged      []string // modified files merged cleanly with the new output
+	Conflicts   []string // modified files merged with conflict markers
+	Created     []string // files the new templates add
+	Skipped     []string // generated files the user deleted
+	Dropped     []string // files the new templates no longer generate
+	Unchanged   int      // files whose template output did not change
+}
+
+// Changed reports whether the upgrade touched any file
+func (r *UpgradeResult) Changed() bool {
+	return len(r.Updated)+len(r.Merged)+len(r.Conflicts)+len(r.Created)+len(r.Dropped) > 0
+}
+
+// recordGenerated saves the generated content of the project files in
+// .hexago/base/ and returns their record for .hexago.yaml
+func (g *ProjectGenerator) recordGenerated() (*HexagoGeneratedConfig, error) {
+	record := &HexagoGeneratedConfig{
+		Version: version.CurrentVersion().Version,
+		Files:   make([]HexagoGeneratedFile, 0, len(g.generated)+len(g.maintained)),
+	}
+
+	for _, f := range g.generated {
+		if err := g.config.writeFile(filepath.Join(g.projectPath, baseDir, f.target), f.content); err != nil {
+			return nil, err
+		}
+		record.Files = append(record.Files, g.config.manifestEntry(f.target, f.source, f.content))
+	}
+	for _, f := range g.maintained {
+		record.Files = append(record.Files, g.config.manifestEntry(f.target, f.source, f.content))
+	}
+
+	return record, nil
+}
+
+// Upgrade renders the project templates of this hexago version and brings
+// their changes into the project: unmodified files are replaced, and files
+// the user edited are three-way merged with the output recorded at
+// generation time, marking conflicts in the file. Everything is committed
+// together.
+func (g *ProjectGenerator) Upgrade() (*UpgradeResult, error) {
+	var result *UpgradeResult
+	err := g.config.transact(func() error {
+		var err error
+		result, err = g.upgrade()
+		return err
+	})
+	return result, err
+}
+
+// upgrade does the work of Upgrade inside its transaction
+func (g *ProjectGenerator) upgrade() (*UpgradeResult, error) {
+	g.projectPath = "."
+
+	content, err := g.config.readFile(HexagoConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", HexagoConfigFile, err)
+	}
+	hexCfg, err := parseHexagoConfig(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render every project file in memory with the current templates
+	renderConfig := *g.config
+	renderConfig.FS = fsys.NewMem()
+	renderConfig.DryRun = false
+	renderConfig.overlay = nil
+	renderConfig.tx = nil
+	render := &ProjectGenerator{config: &renderConfig, projectPath: "."}
+	if err := render.generateFiles(); err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[string]HexagoGeneratedFile, len(hexCfg.Generated.Files))
+	for _, f := range hexCfg.Generated.Files {
+		recorded[f.Path] = f
+	}
+
+	result := &UpgradeResult{FromVersion: hexCfg.Generated.Version}
+	if result.FromVersion == "" {
+		result.FromVersion = "unknown"
+	}
+	labels := utils.MergeLabels{
+		Ours:   "yours",
+		Base:   "hexago " + result.FromVersion,
+		Theirs: "hexago " + version.CurrentVersion().Version,
+	}
+
+	rendered := make(map[string]string, len(render.generated)) // path -> template
+	for _, f := range render.generated {
+		rendered[f.target] = f.source
+		if err := g.upgradeFile(f, recorded, labels, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// The composition root is not merged: it is regenerated from the
+	// components of the project, which run.go may now depend on
+	if err := g.config.rewire(); err != nil {
+		return nil, err
+	}
+
+	// Files generated by other commands are kept in the manifest; project
+	// files are told apart by their base
+	var kept []HexagoGeneratedFile
+	for _, f := range hexCfg.Generated.Files {
+		template, isRendered := rendered[f.Path]
+		basePath := filepath.Join(baseDir, f.Path)
+		switch {
+		case isRendered && f.Template != template:
+			kept = append(kept, f) // regenerated since by another command
+		case isRendered:
+		case g.config.fileExists(basePath):
+			result.Dropped = append(result.Dropped, f.Path)
+			if err := g.config.removeFile(basePath); err != nil {
+				return nil, err
+			}
+		default:
+			kept = append(kept, f)
+		}
+	}
+
+	if !result.Changed() && hexCfg.Generated.Version == version.CurrentVersion().Version {
+		return result, nil
+	}
+
+	// Record the new output as the base of the next upgrade
+	g.generated = render.generated
+	record, err := g.recordGenerated()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range kept {
+		record.Files = slices.DeleteFunc(record.Files, func(r HexagoGeneratedFile) bool { return r.Path == f.Path })
+	}
+	record.Files = append(record.Files, kept...)
+	hexCfg.Generated = *record
+
+	content, err = MarshalHexagoConfig(hexCfg)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("📝 Updating %s\n", HexagoConfigFile)
+	return result, g.config.writeFile(HexagoConfigFile, content)
+}
+
+// upgradeFile brings the new output of a template into its project file
+func (g *ProjectGenerator) upgradeFile(f generatedFile, recorded map[string]HexagoGeneratedFile, labels utils.MergeLabels, result *UpgradeResult) error {
+	record, wasGenerated := recorded[f.target]
+
+	if !g.config.fileExists(f.target) {
+		if wasGenerated {
+			result.Skipped = append(result.Skipped, f.target)
+			return nil
+		}
+		fmt.Printf("📝 Creating %s\n", f.target)
+		result.Created = append(result.Created, f.target)
+		return g.config.writeFile(f.target, f.content)
+	}
+
+	ours, err := g.config.readFile(f.target)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(ours, f.content) {
+		result.Unchanged++
+		return nil
+	}
+
+	// The base is the output recorded at generation time; projects generated
+	// before the record fall back to the lines both versions have in common
+	var base []byte
+	basePath := filepath.Join(baseDir, f.target)
+	if wasGenerated && g.config.fileExists(basePath) {
+		if base, err = g.config.readFile(basePath); err != nil {
+			return err
+		}
+		if record.Template == f.source && hashContent(base) != record.Hash {
+			fmt.Printf("⚠️  Warning: %s does not match the recorded hash of %s\n", basePath, f.target)
+		}
+	} else {
+		base = []byte(utils.CommonLines(string(ours), string(f.content)))
+	}
+
+	switch {
+	case bytes.Equal(base, f.content):
+		result.Unchanged++
+		return nil
+	case bytes.Equal(base, ours):
+		fmt.Printf("📝 Updating %s\n", f.target)
+		result.Updated = append(result.Updated, f.target)
+		return g.config.writeFile(f.target, f.content)
+	}
+
+	merged, conflicts := utils.Merge3(string(base), string(ours), string(f.content), labels)
+	if conflicts > 0 {
+		fmt.Printf("⚠️  Merging %s: %d conflict(s)\n", f.target, conflicts)
+		result.Conflicts = append(result.Conflicts, f.target)
+	} else {
+		fmt.Printf("📝 Merging %s\n", f.target)
+		result.Merged = append(result.Merged, f.target)
+	}
+	return g.config.writeFile(f.target, []byte(merged))
+}
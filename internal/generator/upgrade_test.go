@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestProjectGeneratorUpgrade(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	config.InPlace = true
+
+	// Generate without the go toolchain
+	gen := NewProjectGenerator(config)
+	gen.projectPath = "."
+	if err := config.transact(gen.generateProject); err != nil {
+		t.Fatalf("generateProject() error = %v", err)
+	}
+
+	hexCfg, err := parseHexagoConfig(mustRead(t, mem, HexagoConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hexCfg.Generated.Files) == 0 || hexCfg.Generated.Files[0].Hash != hashContent(mustRead(t, mem, "main.go")) {
+		t.Fatalf("generated files not recorded: %+v", hexCfg.Generated)
+	}
+
+	// Pretend an older hexago generated the Makefile and main.go: the new
+	// templates add a line to each. The Makefile was also edited by the user.
+	makefile := string(mustRead(t, mem, "Makefile"))
+	lines := strings.SplitAfter(makefile, "\n")
+	oldMakefile := strings.Join(slices.Delete(slices.Clone(lines), 2, 3), "")
+	writeBase(t, mem, hexCfg, "Makefile", oldMakefile)
+	mustWrite(t, mem, "Makefile", "# user header\n"+oldMakefile)
+
+	mainGo := string(mustRead(t, mem, "main.go"))
+	oldMainGo := strings.Replace(mainGo, "func main() {", "func main() {\n\t// old", 1)
+	writeBase(t, mem, hexCfg, "main.go", oldMainGo)
+	mustWrite(t, mem, "main.go", oldMainGo)
+
+	if err := mem.Remove(".gitignore"); err != nil {
+		t.Fatal(err)
+	}
+	// A project file newer templates no longer generate, and a file of
+	// hexago add that upgrade leaves alone
+	hexCfg.Generated.Files = append(hexCfg.Generated.Files,
+		HexagoGeneratedFile{Path: "old.txt", Template: "misc/old.tmpl"},
+		HexagoGeneratedFile{Path: "internal/core/domain/user.go", Template: "domain/entity.go.tmpl"},
+	)
+	mustWrite(t, mem, ".hexago/base/old.txt", "old\n")
+	content, _ := MarshalHexagoConfig(hexCfg)
+	mustWrite(t, mem, HexagoConfigFile, string(content))
+
+	result, err := NewProjectGenerator(config).Upgrade()
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+
+	if !slices.Equal(result.Merged, []string{"Makefile"}) {
+		t.Errorf("Merged = %v, want [Makefile]", result.Merged)
+	}
+	if !slices.Equal(result.Updated, []string{"main.go"}) {
+		t.Errorf("Updated = %v, want [main.go]", result.Updated)
+	}
+	if !slices.Equal(result.Skipped, []string{".gitignore"}) {
+		t.Errorf("Skipped = %v, want [.gitignore]", result.Skipped)
+	}
+	if !slices.Equal(result.Dropped, []string{"old.txt"}) {
+		t.Errorf("Dropped = %v, want [old.txt]", result.Dropped)
+	}
+	if len(result.Conflicts) > 0 {
+		t.Errorf("Conflicts = %v, want none", result.Conflicts)
+	}
+
+	if got := string(mustRead(t, mem, "Makefile")); got != "# user header\n"+makefile {
+		t.Errorf("Makefile not merged:\n%s", got)
+	}
+	if got := string(mustRead(t, mem, "main.go")); got != mainGo {
+		t.Errorf("main.go not updated:\n%s", got)
+	}
+	if got := string(mustRead(t, mem, ".hexago/base/Makefile")); got != makefile {
+		t.Errorf("base of Makefile not updated:\n%s", got)
+	}
+	if fsys.Exists(mem, ".hexago/base/old.txt") {
+		t.Error("base of old.txt not removed")
+	}
+
+	hexCfg, err = parseHexagoConfig(mustRead(t, mem, HexagoConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range hexCfg.Generated.Files {
+		paths = append(paths, f.Path)
+	}
+	if slices.Contains(paths, "old.txt") || !slices.Contains(paths, "internal/core/domain/user.go") {
+		t.Errorf("manifest after upgrade = %v", paths)
+	}
+
+	// A second run has nothing to do
+	result, err = NewProjectGenerator(config).Upgrade()
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if result.Changed() {
+		t.Errorf("second Upgrade() changed files: %+v", result)
+	}
+}
+
+func mustRead(t *testing.T, fs fsys.FS, path string) []byte {
+	t.Helper()
+	content, err := fs.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func mustWrite(t *testing.T, fs fsys.FS, path, content string) {
+	t.Helper()
+	if err := fsys.WriteFile(fs, path, []byte(content)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeBase replaces the recorded output of path
+func writeBase(t *testing.T, fs fsys.FS, hexCfg *HexagoConfig, path, content string) {
+	t.Helper()
+	mustWrite(t, fs, ".hexago/base/"+path, content)
+	for i, f := range hexCfg.Generated.Files {
+		if f.Path == path {
+			hexCfg.Generated.Files[i].Hash = hashContent([]byte(content))
+		}
+	}
+}
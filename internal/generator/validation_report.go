@@ -0,0 +1,234 @@
+package generator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ValidationFormats are the output formats of a ValidationResult besides text
+var ValidationFormats = []string{"json", "sarif", "junit"}
+
+// WriteReport writes the result in one of ValidationFormats. name identifies
+// the validated project in the JUnit test suite, version the hexago version
+// in the SARIF log.
+func (r *ValidationResult) WriteReport(w io.Writer, format, name, version string) error {
+	switch format {
+	case "json":
+		return r.WriteJSON(w)
+	case "sarif":
+		return r.WriteSARIF(w, version)
+	case "junit":
+		return r.WriteJUnit(w, name)
+	default:
+		return fmt.Errorf("unknown format %q: use text, json, sarif or junit", format)
+	}
+}
+
+// validationJSON is the JSON document of a ValidationResult
+type validationJSON struct {
+	Successes  []ValidationCheck `json:"successes"`
+	Violations []Violation       `json:"violations"`
+	Summary    struct {
+		Passed   int `json:"passed"`
+		Warnings int `json:"warnings"`
+		Errors   int `json:"errors"`
+	} `json:"summary"`
+}
+
+// WriteJSON writes the result as a JSON document
+func (r *ValidationResult) WriteJSON(w io.Writer) error {
+	doc := validationJSON{Successes: r.Successes, Violations: r.Violations}
+	if doc.Successes == nil {
+		doc.Successes = []ValidationCheck{}
+	}
+	if doc.Violations == nil {
+		doc.Violations = []Violation{}
+	}
+	doc.Summary.Passed = len(r.Successes)
+	doc.Summary.Warnings = len(r.Warnings())
+	doc.Summary.Errors = r.ErrorCount()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// sarifLog is the subset of SARIF 2.1.0 hexago writes, enough for code
+// scanning annotations
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF writes the violations as a SARIF 2.1.0 log. Locations are
+// relative to the project root (%SRCROOT%).
+func (r *ValidationResult) WriteSARIF(w io.Writer, version string) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "hexago",
+			Version:        version,
+			InformationURI: "https://github.com/padiazg/hexago",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	var rules []string
+	for _, v := range r.Violations {
+		index := slices.Index(rules, v.Rule)
+		if index < 0 {
+			index = len(rules)
+			rules = append(rules, v.Rule)
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: v.Rule})
+		}
+
+		result := sarifResult{
+			RuleID:    v.Rule,
+			RuleIndex: index,
+			Level:     v.Severity,
+			Message:   sarifMessage{Text: v.Message},
+		}
+		if v.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: v.File, URIBaseID: "%SRCROOT%"},
+			}}
+			if v.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: v.Line, StartColumn: v.Column}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// junitTestSuites is the JUnit XML report of a ValidationResult
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the result as a JUnit XML report: a passing test case per
+// check that passed, one per violation, the errors failing. Warnings don't
+// fail the validation, so their test cases pass with the warning as output.
+func (r *ValidationResult) WriteJUnit(w io.Writer, name string) error {
+	suite := junitTestSuite{Name: name, TestCases: []junitTestCase{}}
+	for _, check := range r.Successes {
+		suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: check.Rule, Name: check.Message})
+	}
+	for _, v := range r.Violations {
+		testCase := junitTestCase{ClassName: v.Rule, Name: v.Message}
+		if v.File != "" {
+			testCase.Name = fmt.Sprintf("%s (%s)", v.Message, v.Location())
+		}
+		if v.Severity == SeverityError {
+			testCase.Failure = &junitFailure{Type: v.Severity, Message: v.Message, Text: v.String()}
+			suite.Failures++
+		} else {
+			testCase.SystemOut = "warning: " + v.String()
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	content, err := xml.MarshalIndent(junitTestSuites{
+		Name:     "hexago validate",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", content)
+	return err
+}
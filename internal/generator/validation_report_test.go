@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+// testValidationResult validates a project whose domain imports an outbound
+// adapter, and whose config directory is missing
+func testValidationResult(t *testing.T) *ValidationResult {
+	t.Helper()
+
+	mem := fsys.NewMem()
+	for path, content := range map[string]string{
+		"internal/core/domain/orders/order.go": `package orders
+
+import (
+	"fmt"
+
+	db "example.com/demo/internal/adapters/secondary/database/orders"
+)
+`,
+		"internal/core/services/orders/orders.go":               "package orders\n",
+		"internal/adapters/primary/http/http.go":                "package http\n",
+		"internal/adapters/secondary/database/orders/orders.go": "package orders\n",
+	} {
+		mustWrite(t, mem, path, content)
+	}
+
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	return NewValidator(config).Validate()
+}
+
+func TestValidationResultViolations(t *testing.T) {
+	result := testValidationResult(t)
+
+	want := Violation{
+		Rule:       "domain-isolation",
+		Severity:   SeverityError,
+		File:       "internal/core/domain/orders/order.go",
+		Line:       6,
+		Column:     5,
+		ImportPath: "example.com/demo/internal/adapters/secondary/database/orders",
+		Message:    "domain layer imports outbound layer: example.com/demo/internal/adapters/secondary/database/orders",
+	}
+	if errors := result.Errors(); len(errors) != 1 || errors[0] != want {
+		t.Errorf("Errors() = %+v, want %+v", errors, want)
+	}
+
+	warning := Violation{Rule: "project-structure", Severity: SeverityWarning, File: "internal/config", Message: "Config directory not found"}
+	if warnings := result.Warnings(); len(warnings) != 1 || warnings[0] != warning {
+		t.Errorf("Warnings() = %+v, want %+v", warnings, warning)
+	}
+}
+
+func TestValidationResultWriteJSON(t *testing.T) {
+	result := testValidationResult(t)
+
+	var b bytes.Buffer
+	if err := result.WriteReport(&b, "json", "demo", "v1.0.0"); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	var doc validationJSON
+	if err := json.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if len(doc.Violations) != 2 || doc.Violations[1].Line != 6 || doc.Violations[1].Column != 5 {
+		t.Errorf("violations = %+v", doc.Violations)
+	}
+	if doc.Summary.Passed != len(result.Successes) || doc.Summary.Warnings != 1 || doc.Summary.Errors != 1 {
+		t.Errorf("summary = %+v", doc.Summary)
+	}
+	if !strings.Contains(b.String(), `"import_path": "example.com/demo/internal/adapters/secondary/database/orders"`) {
+		t.Errorf("import path missing:\n%s", b.String())
+	}
+}
+
+func TestValidationResultWriteSARIF(t *testing.T) {
+	result := &ValidationResult{Violations: []Violation{
+		{Rule: "domain-isolation", Severity: SeverityError, File: "internal/core/domain/a.go", Line: 3, Column: 2, Message: "a"},
+		{Rule: "migration-versions", Severity: SeverityWarning, Message: "b"},
+		{Rule: "domain-isolation", Severity: SeverityError, File: "internal/core/domain/c.go", Message: "c"},
+	}}
+
+	var b bytes.Buffer
+	if err := result.WriteReport(&b, "sarif", "demo", "v1.0.0"); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(b.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log:\n%s", b.String())
+	}
+
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "hexago" || run.Tool.Driver.Version != "v1.0.0" {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[1].ID != "migration-versions" {
+		t.Errorf("rules = %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %+v", run.Results)
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" || first.RuleIndex != 0 || len(first.Locations) != 1 {
+		t.Errorf("result = %+v", first)
+	} else if loc := first.Locations[0].PhysicalLocation; loc.ArtifactLocation.URI != "internal/core/domain/a.go" ||
+		loc.Region == nil || loc.Region.StartLine != 3 || loc.Region.StartColumn != 2 {
+		t.Errorf("location = %+v", loc)
+	}
+	if second := run.Results[1]; second.Level != "warning" || second.RuleIndex != 1 || second.Locations != nil {
+		t.Errorf("result = %+v", second)
+	}
+	if third := run.Results[2]; third.RuleIndex != 0 || third.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("result = %+v", third)
+	}
+}
+
+func TestValidationResultWriteJUnit(t *testing.T) {
+	result := testValidationResult(t)
+
+	var b bytes.Buffer
+	if err := result.WriteReport(&b, "junit", "demo", "v1.0.0"); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	if !strings.HasPrefix(b.String(), xml.Header) {
+		t.Errorf("missing XML header:\n%s", b.String())
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(b.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, b.String())
+	}
+
+	tests := len(result.Successes) + len(result.Violations)
+	if report.Tests != tests || report.Failures != 1 || len(report.Suites) != 1 || report.Suites[0].Name != "demo" {
+		t.Fatalf("unexpected report:\n%s", b.String())
+	}
+
+	var failed, warned int
+	for _, tc := range report.Suites[0].TestCases {
+		if tc.Failure != nil {
+			failed++
+			if tc.ClassName != "domain-isolation" || tc.Name != "domain layer imports outbound layer: example.com/demo/internal/adapters/secondary/database/orders (internal/core/domain/orders/order.go:6)" {
+				t.Errorf("failed test case = %+v", tc)
+			}
+		}
+		if tc.SystemOut != "" {
+			warned++
+			if tc.SystemOut != "warning: project-structure: Config directory not found in internal/config" {
+				t.Errorf("warning test case = %+v", tc)
+			}
+		}
+	}
+	if failed != 1 || warned != 1 {
+		t.Errorf("failed = %d, warned = %d, want 1 and 1", failed, warned)
+	}
+}
+
+func TestValidationResultWriteReportUnknownFormat(t *testing.T) {
+	if err := (&ValidationResult{}).WriteReport(&bytes.Buffer{}, "xml", "demo", ""); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
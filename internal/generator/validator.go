@@ -2,28 +2,109 @@ package generator
 
 import (
 	"fmt"
+	"go/ast"
+	"go/build"
+	"go/build/constraint"
 	"go/parser"
 	"go/token"
-	"os"
+	"io/fs"
+	"maps"
+	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// Violation severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
 )
 
+// ValidationCheck is a check that passed
+type ValidationCheck struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Violation is a finding of a validation check. File is relative to the
+// project root; Line and Column are 1-based, and zero when unknown.
+type Violation struct {
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	File       string `json:"file,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
+	ImportPath string `json:"import_path,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Location returns where the violation was found, e.g. "internal/core/domain/user.go:5"
+func (v Violation) Location() string {
+	if v.Line == 0 {
+		return v.File
+	}
+	return fmt.Sprintf("%s:%d", v.File, v.Line)
+}
+
+// String returns the violation as a line of text output
+func (v Violation) String() string {
+	if v.File == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%s: %s in %s", v.Rule, v.Message, v.Location())
+}
+
 // ValidationResult holds validation results
 type ValidationResult struct {
-	Successes []string
-	Warnings  []string
-	Errors    []string
+	Successes  []ValidationCheck
+	Violations []Violation
+}
+
+// pass records a check that passed
+func (r *ValidationResult) pass(rule, message string) {
+	r.Successes = append(r.Successes, ValidationCheck{Rule: rule, Message: message})
+}
+
+// report records a violation of a check, found in file when it is not empty
+func (r *ValidationResult) report(rule, severity, file, message string) {
+	r.Violations = append(r.Violations, Violation{Rule: rule, Severity: severity, File: file, Message: message})
+}
+
+// Errors returns the violations of error severity
+func (r *ValidationResult) Errors() []Violation {
+	return r.bySeverity(SeverityError)
+}
+
+// Warnings returns the violations of warning severity
+func (r *ValidationResult) Warnings() []Violation {
+	return r.bySeverity(SeverityWarning)
+}
+
+// bySeverity returns the violations of the given severity
+func (r *ValidationResult) bySeverity(severity string) []Violation {
+	violations := make([]Violation, 0)
+	for _, v := range r.Violations {
+		if v.Severity == severity {
+			violations = append(violations, v)
+		}
+	}
+	return violations
 }
 
 // HasErrors returns true if there are any errors
 func (r *ValidationResult) HasErrors() bool {
-	return len(r.Errors) > 0
+	return r.ErrorCount() > 0
 }
 
 // ErrorCount returns the number of errors
 func (r *ValidationResult) ErrorCount() int {
-	return len(r.Errors)
+	return len(r.Errors())
 }
 
 // Validator validates hexagonal architecture compliance
@@ -41,132 +122,134 @@ func NewValidator(config *ProjectConfig) *Validator {
 // Validate runs all validation checks
 func (v *Validator) Validate() *ValidationResult {
 	result := &ValidationResult{
-		Successes: make([]string, 0),
-		Warnings:  make([]string, 0),
-		Errors:    make([]string, 0),
+		Successes:  make([]ValidationCheck, 0),
+		Violations: make([]Violation, 0),
 	}
 
 	// Check 1: Project structure
 	v.validateProjectStructure(result)
 
-	// Check 2: Core domain dependencies
-	v.validateCoreDependencies(result)
-
-	// Check 3: Service/UseCase dependencies
-	v.validateServiceDependencies(result)
-
-	// Check 4: Adapter dependencies
-	v.validateAdapterDependencies(result)
+	// Check 2: Layer dependencies
+	v.validateArchitectureRules(result)
 
-	// Check 5: Naming conventions
+	// Check 3: Naming conventions
 	v.validateNamingConventions(result)
 
 	return result
 }
 
+// requiredDir is a directory every project needs
+type requiredDir struct {
+	path        string
+	description string
+}
+
+// requiredDirs returns the directories every project needs
+func (c *ProjectConfig) requiredDirs() []requiredDir {
+	return []requiredDir{
+		{filepath.Join("internal", "core", "domain"), "Domain directory"},
+		{filepath.Join("internal", "core", c.CoreLogicDir()), "Core logic directory"},
+		{filepath.Join("internal", "adapters", c.AdapterInboundDir()), "Inbound adapters directory"},
+		{filepath.Join("internal", "adapters", c.AdapterOutboundDir()), "Outbound adapters directory"},
+		{filepath.Join("internal", "config"), "Config directory"},
+	}
+}
+
+// otherAdapterDirs maps the adapter directory names of each adapter style
+// to the ones of the other style
+var otherAdapterDirs = map[string]string{
+	"primary":   "driver",
+	"secondary": "driven",
+	"driver":    "primary",
+	"driven":    "secondary",
+}
+
+// misnamedAdapterDirs maps the adapter directories named after the other
+// adapter style to the ones the adapter style of the project expects
+func (c *ProjectConfig) misnamedAdapterDirs() map[string]string {
+	dirs := make(map[string]string)
+	for _, expected := range []string{c.AdapterInboundDir(), c.AdapterOutboundDir()} {
+		misnamed := filepath.Join("internal", "adapters", otherAdapterDirs[expected])
+		if c.isDir(misnamed) {
+			dirs[misnamed] = filepath.Join("internal", "adapters", expected)
+		}
+	}
+	return dirs
+}
+
 // validateProjectStructure checks if required directories exist
 func (v *Validator) validateProjectStructure(result *ValidationResult) {
-	requiredDirs := []struct {
-		path        string
-		description string
-	}{
-		{"internal/core/domain", "Domain directory"},
-		{filepath.Join("internal/core", v.config.CoreLogicDir()), "Core logic directory"},
-		{filepath.Join("internal/adapters", v.config.AdapterInboundDir()), "Inbound adapters directory"},
-		{filepath.Join("internal/adapters", v.config.AdapterOutboundDir()), "Outbound adapters directory"},
-		{"internal/config", "Config directory"},
-	}
-
-	for _, dir := range requiredDirs {
-		if _, err := os.Stat(dir.path); err == nil {
-			result.Successes = append(result.Successes, fmt.Sprintf("%s exists", dir.description))
+	for _, dir := range v.config.requiredDirs() {
+		if v.config.isDir(dir.path) {
+			result.pass("project-structure", fmt.Sprintf("%s exists", dir.description))
 		} else {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("%s not found: %s", dir.description, dir.path))
+			result.report("project-structure", SeverityWarning, filepath.ToSlash(dir.path), fmt.Sprintf("%s not found", dir.description))
 		}
 	}
 }
 
-// validateCoreDependencies ensures core/domain has no external dependencies
-func (v *Validator) validateCoreDependencies(result *ValidationResult) {
-	domainPath := filepath.Join("internal", "core", "domain")
-
-	violations, err := v.checkImports(domainPath, func(importPath string) bool {
-		// Domain should not import from adapters or infrastructure
-		return !strings.Contains(importPath, "/adapters/") &&
-			!strings.Contains(importPath, "/infrastructure/")
-	})
-
+// validateArchitectureRules checks the imports of every layer against the
+// architecture rules of .hexago.yaml
+func (v *Validator) validateArchitectureRules(result *ValidationResult) {
+	arch, err := v.config.architecture()
 	if err != nil {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Could not check domain dependencies: %v", err))
+		result.report("architecture-rules", SeverityError, HexagoConfigFile, fmt.Sprintf("Invalid architecture rules: %v", err))
 		return
 	}
 
-	if len(violations) == 0 {
-		result.Successes = append(result.Successes, "Core domain has no external dependencies")
-	} else {
-		for _, v := range violations {
-			result.Errors = append(result.Errors, fmt.Sprintf("Domain imports external package: %s in %s", v.importPath, v.file))
-		}
+	var std map[string]bool
+	imports, err := v.imports()
+	if err == nil {
+		std, err = analyzer.StandardPackages()
 	}
-}
-
-// validateServiceDependencies ensures services only depend on domain and ports
-func (v *Validator) validateServiceDependencies(result *ValidationResult) {
-	servicePath := filepath.Join("internal", "core", v.config.CoreLogicDir())
-
-	violations, err := v.checkImports(servicePath, func(importPath string) bool {
-		// Services can import domain and ports, but not adapters
-		if strings.Contains(importPath, v.config.ModuleName) {
-			return !strings.Contains(importPath, "/adapters/")
-		}
-		return true
-	})
-
 	if err != nil {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Could not check %s dependencies: %v", v.config.CoreLogicDir(), err))
+		result.report("architecture-rules", SeverityWarning, "", fmt.Sprintf("Could not check layer dependencies: %v", err))
 		return
 	}
 
-	if len(violations) == 0 {
-		result.Successes = append(result.Successes, fmt.Sprintf("%s only depend on domain and ports", strings.Title(v.config.CoreLogicDir())))
-	} else {
-		for _, violation := range violations {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s imports adapter: %s in %s", strings.Title(v.config.CoreLogicDir()), violation.importPath, violation.file))
-		}
-	}
-}
+	for _, rule := range arch.Rules {
+		violations := 0
+		for _, imp := range imports {
+			if arch.layerOf(path.Dir(imp.file)) != rule.Layer {
+				continue
+			}
 
-// validateAdapterDependencies ensures adapters don't import from other adapters
-func (v *Validator) validateAdapterDependencies(result *ValidationResult) {
-	adaptersPath := filepath.Join("internal", "adapters")
+			var message string
+			if imp.external() {
+				if rule.allowsExternal(imp.importPath, std) {
+					continue
+				}
+				message = fmt.Sprintf("%s layer imports external package: %s", rule.Layer, imp.importPath)
+			} else {
+				to := arch.layerOf(imp.dir)
+				if rule.allows(to) {
+					continue
+				}
+				message = fmt.Sprintf("%s layer imports %s layer: %s", rule.Layer, to, imp.importPath)
+			}
 
-	violations, err := v.checkImports(adaptersPath, func(importPath string) bool {
-		// Adapters can import from core, but not from other adapters
-		// Allow same-type adapter imports (e.g., primary/http can import primary/http)
-		if strings.Contains(importPath, "/adapters/") {
-			// Get the import adapter type
-			parts := strings.Split(importPath, "/adapters/")
-			if len(parts) > 1 {
-				importAdapterType := strings.Split(parts[1], "/")[0]
-				// This is a simplified check - could be more sophisticated
-				_ = importAdapterType
-				// For now, allow adapter imports (too strict otherwise)
-				return true
+			violations++
+			severity := rule.Severity
+			if severity == "" {
+				severity = SeverityError
 			}
+			result.Violations = append(result.Violations, Violation{
+				Rule:       rule.ID,
+				Severity:   severity,
+				File:       imp.file,
+				Line:       imp.line,
+				Column:     imp.column,
+				ImportPath: imp.importPath,
+				Message:    message,
+			})
 		}
-		return true
-	})
 
-	if err != nil {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Could not check adapter dependencies: %v", err))
-		return
-	}
-
-	if len(violations) == 0 {
-		result.Successes = append(result.Successes, "Adapters follow dependency rules")
-	} else {
-		for _, violation := range violations {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("Adapter cross-import: %s in %s", violation.importPath, violation.file))
+		if violations == 0 {
+			description := rule.Description
+			if description == "" {
+				description = fmt.Sprintf("%s layer follows its import rules", utils.ToTitleCase(rule.Layer))
+			}
+			result.pass(rule.ID, description)
 		}
 	}
 }
@@ -182,68 +265,149 @@ func (v *Validator) validateNamingConventions(result *ValidationResult) {
 	inboundPath := filepath.Join(adaptersPath, expectedInbound)
 	outboundPath := filepath.Join(adaptersPath, expectedOutbound)
 
-	if _, err := os.Stat(inboundPath); err == nil {
-		result.Successes = append(result.Successes, fmt.Sprintf("Using %s for inbound adapters", expectedInbound))
+	if v.config.isDir(inboundPath) {
+		result.pass("naming-conventions", fmt.Sprintf("Using %s for inbound adapters", expectedInbound))
+	}
+
+	if v.config.isDir(outboundPath) {
+		result.pass("naming-conventions", fmt.Sprintf("Using %s for outbound adapters", expectedOutbound))
 	}
 
-	if _, err := os.Stat(outboundPath); err == nil {
-		result.Successes = append(result.Successes, fmt.Sprintf("Using %s for outbound adapters", expectedOutbound))
+	// Adapter directories named after the other adapter style
+	misnamed := v.config.misnamedAdapterDirs()
+	for _, dir := range slices.Sorted(maps.Keys(misnamed)) {
+		result.report("naming-conventions", SeverityWarning, filepath.ToSlash(dir),
+			fmt.Sprintf("Adapter directory should be named %s (adapter style %s)", filepath.Base(misnamed[dir]), v.config.AdapterStyle))
 	}
 
 	// Check for consistent naming
 	// Check if core logic directory matches expected
 	coreLogicPath := filepath.Join("internal", "core", v.config.CoreLogicDir())
-	if _, err := os.Stat(coreLogicPath); err == nil {
-		result.Successes = append(result.Successes, fmt.Sprintf("Using %s for business logic", v.config.CoreLogicDir()))
+	if v.config.isDir(coreLogicPath) {
+		result.pass("naming-conventions", fmt.Sprintf("Using %s for business logic", v.config.CoreLogicDir()))
 	}
 }
 
-// importViolation represents an import that violates architecture rules
-type importViolation struct {
-	file       string
+// fileImport is an import of a Go file of the project
+type fileImport struct {
+	file       string // importing file, relative to the project root
+	line       int
+	column     int
 	importPath string
+	dir        string // imported project package, relative to the project root; empty for other modules and the standard library
 }
 
-// checkImports checks all Go files in a directory for import violations
-func (v *Validator) checkImports(dir string, isAllowed func(string) bool) ([]importViolation, error) {
-	var violations []importViolation
+// external reports whether the imported package is not a project package
+func (i fileImport) external() bool {
+	return i.dir == ""
+}
+
+// imports returns the imports in the Go files of the project, test files,
+// files excluded by their build constraints, vendor, testdata and hidden
+// directories excluded
+func (v *Validator) imports() ([]fileImport, error) {
+	var imports []fileImport
+
+	filesystem := v.config.filesystem()
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	err := fsys.WalkDir(filesystem, ".", func(name string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip non-Go files and test files
-		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+		if d.IsDir() {
+			base := d.Name()
+			if name != "." && (base == "vendor" || base == "testdata" || strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
 			return nil
 		}
 
 		// Parse file
+		content, err := filesystem.ReadFile(name)
+		if err != nil {
+			return nil // Skip files that can't be read
+		}
 		fset := token.NewFileSet()
-		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		file, err := parser.ParseFile(fset, name, content, parser.ImportsOnly|parser.ParseComments)
 		if err != nil {
 			return nil // Skip files that can't be parsed
 		}
+		if !buildable(file) {
+			return nil
+		}
 
-		// Check imports
 		for _, imp := range file.Imports {
-			importPath := strings.Trim(imp.Path.Value, `"`)
-
-			// Only check imports from the same module
-			if !strings.HasPrefix(importPath, v.config.ModuleName) {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
 				continue
 			}
-
-			if !isAllowed(importPath) {
-				violations = append(violations, importViolation{
-					file:       path,
-					importPath: importPath,
-				})
+			position := fset.Position(imp.Path.Pos())
+
+			// Only project packages belong to a layer
+			var dir string
+			if importPath == v.config.ModuleName {
+				dir = "."
+			} else if rest, ok := strings.CutPrefix(importPath, v.config.ModuleName+"/"); ok {
+				dir = rest
 			}
+
+			imports = append(imports, fileImport{
+				file:       filepath.ToSlash(name),
+				line:       position.Line,
+				column:     position.Column,
+				importPath: importPath,
+				dir:        dir,
+			})
 		}
 
 		return nil
 	})
 
-	return violations, err
+	return imports, err
+}
+
+// buildable reports whether the //go:build constraint of file, if any, is
+// satisfied by the default build context, as the go command would
+func buildable(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(comment.Text)
+			if err == nil && !expr.Eval(matchBuildTag) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// unixOS are the values of GOOS the "unix" build tag matches
+var unixOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+	"illumos": true, "ios": true, "linux": true, "netbsd": true, "openbsd": true, "solaris": true,
+}
+
+// matchBuildTag reports whether the default build context satisfies tag
+func matchBuildTag(tag string) bool {
+	ctx := build.Default
+	switch {
+	case tag == ctx.GOOS, tag == ctx.GOARCH, tag == ctx.Compiler:
+		return true
+	case tag == "unix":
+		return unixOS[ctx.GOOS]
+	case tag == "cgo":
+		return ctx.CgoEnabled
+	case tag == "linux" && ctx.GOOS == "android", tag == "darwin" && ctx.GOOS == "ios", tag == "solaris" && ctx.GOOS == "illumos":
+		return true
+	}
+	return slices.Contains(ctx.BuildTags, tag) || slices.Contains(ctx.ToolTags, tag) || slices.Contains(ctx.ReleaseTags, tag)
 }
@@ -0,0 +1,370 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/padiazg/hexago/internal/analyzer"
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestValidatorGeneratedProject(t *testing.T) {
+	for _, projectType := range []string{"http-server", "service", "cli"} {
+		t.Run(projectType, func(t *testing.T) {
+			mem := fsys.NewMem()
+			config := NewProjectConfig("demo", "example.com/demo")
+			config.FS = mem
+			config.InPlace = true
+			config.ProjectType = projectType
+			config.WithObservability = true
+
+			gen := NewProjectGenerator(config)
+			gen.projectPath = "."
+			if err := config.transact(gen.generateProject); err != nil {
+				t.Fatalf("generateProject() error = %v", err)
+			}
+
+			result := NewValidator(config).Validate()
+			if len(result.Violations) > 0 {
+				t.Errorf("Validate() violations = %q", violationStrings(result.Violations))
+			}
+		})
+	}
+}
+
+func TestValidatorArchitectureRules(t *testing.T) {
+	files := map[string]string{
+		// mycorp/money is a module whose path has no domain name
+		"internal/core/domain/orders/total.go": `package orders
+
+import "mycorp/money"
+`,
+		"internal/core/domain/orders/order.go": `package orders
+
+import (
+	"database/sql"
+	"fmt"
+
+	"example.com/demo/internal/config"
+	"github.com/google/uuid"
+)
+`,
+		"internal/core/services/orders/orders.go": `package orders
+
+import (
+	"net/http"
+
+	"example.com/demo/internal/adapters/secondary/database/orders"
+	"github.com/google/uuid"
+)
+`,
+		"internal/adapters/primary/http/orders/handlers.go": `package orders
+
+import (
+	"example.com/demo/internal/adapters/primary/http/health"
+	"example.com/demo/internal/adapters/secondary/database/orders"
+	"example.com/demo/internal/core/services"
+)
+`,
+		"internal/adapters/secondary/database/orders/orders.go": "package orders\n",
+		"internal/config/config.go":                             "package config\n",
+		// Skipped: test files, files excluded by build constraints and
+		// hidden directories
+		"internal/core/domain/orders/tools.go": `//go:build ignore
+
+package orders
+
+import "example.com/demo/internal/adapters/secondary/database/orders"
+`,
+		"internal/core/domain/orders/order_test.go": `package orders
+
+import "example.com/demo/internal/adapters/secondary/database/orders"
+`,
+		".hexago/base/internal/core/domain/orders/order.go": `package orders
+
+import "example.com/demo/internal/adapters/secondary/database/orders"
+`,
+	}
+
+	tests := []struct {
+		name     string
+		rules    string
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "default rules",
+			errors: []string{
+				"domain-isolation: domain layer imports infrastructure layer: example.com/demo/internal/config in internal/core/domain/orders/order.go:7",
+				"domain-external: domain layer imports external package: database/sql in internal/core/domain/orders/order.go:4",
+				"domain-external: domain layer imports external package: github.com/google/uuid in internal/core/domain/orders/order.go:8",
+				"domain-external: domain layer imports external package: mycorp/money in internal/core/domain/orders/total.go:3",
+				"core-dependencies: core layer imports outbound layer: example.com/demo/internal/adapters/secondary/database/orders in internal/core/services/orders/orders.go:6",
+			},
+			warnings: []string{
+				"core-external: core layer imports external package: net/http in internal/core/services/orders/orders.go:4",
+				"inbound-adapters: inbound layer imports outbound layer: example.com/demo/internal/adapters/secondary/database/orders in internal/adapters/primary/http/orders/handlers.go:5",
+			},
+		},
+		{
+			name: "declared rules",
+			rules: `architecture:
+  rules:
+    - id: no-adapter-cross-imports
+      layer: inbound
+      may_import: [core, domain, ports, pkg]
+    - id: lenient-domain
+      layer: domain
+      may_not_import: [inbound, outbound]
+      severity: warning
+`,
+			errors: []string{
+				"no-adapter-cross-imports: inbound layer imports outbound layer: example.com/demo/internal/adapters/secondary/database/orders in internal/adapters/primary/http/orders/handlers.go:5",
+			},
+		},
+		{
+			name: "declared layers",
+			rules: `architecture:
+  layers:
+    - name: orders
+      paths: [internal/core/domain/orders, internal/core/services/orders]
+    - name: config
+      paths: [internal/config]
+  rules:
+    - id: orders-config
+      layer: orders
+      may_not_import: [config]
+`,
+			errors: []string{
+				"orders-config: orders layer imports config layer: example.com/demo/internal/config in internal/core/domain/orders/order.go:7",
+			},
+		},
+		{
+			name: "declared external packages",
+			rules: `architecture:
+  rules:
+    - id: domain-packages
+      layer: domain
+      allow_external: [std, github.com/google/uuid]
+      deny_external: [database/sql]
+    - id: core-packages
+      layer: core
+      deny_external: [net]
+`,
+			errors: []string{
+				"domain-packages: domain layer imports external package: database/sql in internal/core/domain/orders/order.go:4",
+				"domain-packages: domain layer imports external package: mycorp/money in internal/core/domain/orders/total.go:3",
+				"core-packages: core layer imports external package: net/http in internal/core/services/orders/orders.go:4",
+			},
+		},
+		{
+			name: "invalid rules",
+			rules: `architecture:
+  rules:
+    - id: domain
+      layer: domain
+      may_import: [adapters]
+`,
+			errors: []string{
+				`architecture-rules: Invalid architecture rules: rule "domain": unknown layer "adapters" in .hexago.yaml`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := fsys.NewMem()
+			for path, content := range files {
+				mustWrite(t, mem, path, content)
+			}
+			if tt.rules != "" {
+				mustWrite(t, mem, HexagoConfigFile, tt.rules)
+			}
+
+			config := NewProjectConfig("demo", "example.com/demo")
+			config.FS = mem
+
+			result := NewValidator(config).Validate()
+			if got := violationStrings(result.Errors()); !slices.Equal(got, tt.errors) {
+				t.Errorf("Errors() = %q, want %q", got, tt.errors)
+			}
+			if got := violationStrings(result.Warnings()); !slices.Equal(got, tt.warnings) {
+				t.Errorf("Warnings() = %q, want %q", got, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestArchitectureCheck(t *testing.T) {
+	layers := []HexagoLayer{
+		{Name: "domain", Paths: []string{"internal/core/domain"}},
+		{Name: "core", Paths: []string{"internal/core/services"}},
+	}
+
+	tests := []struct {
+		name    string
+		arch    HexagoArchitectureConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "domain", MayImport: []string{"domain"}},
+				{ID: "b", Layer: "core", MayNotImport: []string{"domain"}, Severity: "warning"},
+			}},
+		},
+		{
+			name:    "duplicate layer",
+			arch:    HexagoArchitectureConfig{Layers: append(layers, layers[0])},
+			wantErr: `layer "domain" declared twice`,
+		},
+		{
+			name:    "layer without paths",
+			arch:    HexagoArchitectureConfig{Layers: []HexagoLayer{{Name: "domain"}}},
+			wantErr: `layer "domain" needs a name and paths`,
+		},
+		{
+			name: "duplicate rule",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "domain", MayImport: []string{"domain"}},
+				{ID: "a", Layer: "core", MayImport: []string{"domain"}},
+			}},
+			wantErr: `rule "a" declared twice`,
+		},
+		{
+			name: "no import lists",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "domain"},
+			}},
+			wantErr: `rule "a" needs may_import, may_not_import, allow_external or deny_external`,
+		},
+		{
+			name: "external packages only",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "domain", AllowExternal: []string{"std"}, DenyExternal: []string{"net/http"}},
+			}},
+		},
+		{
+			name: "invalid package pattern",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "domain", DenyExternal: []string{"net/"}},
+			}},
+			wantErr: `rule "a": invalid package pattern "net/"`,
+		},
+		{
+			name: "both import lists",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "core", MayImport: []string{"domain"}, MayNotImport: []string{"domain"}},
+			}},
+			wantErr: `rule "a" needs either may_import or may_not_import`,
+		},
+		{
+			name: "unknown severity",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "domain", MayImport: []string{"domain"}, Severity: "fatal"},
+			}},
+			wantErr: `rule "a": severity must be error or warning, got "fatal"`,
+		},
+		{
+			name: "unknown layer",
+			arch: HexagoArchitectureConfig{Layers: layers, Rules: []HexagoRule{
+				{ID: "a", Layer: "adapters", MayImport: []string{"domain"}},
+			}},
+			wantErr: `rule "a": unknown layer "adapters"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.arch.check()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArchitectureLayerOf(t *testing.T) {
+	arch := NewProjectConfig("demo", "example.com/demo").defaultArchitecture()
+	arch.Layers = append(arch.Layers, HexagoLayer{Name: "shared", Paths: []string{"internal/core/domain/shared/"}})
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"internal/core/domain", "domain"},
+		{"internal/core/domain/orders", "domain"},
+		{"internal/core/domain/shared/money", "shared"},
+		{"internal/core/domainx", ""},
+		{"internal/core/services/orders", "core"},
+		{"internal/adapters/primary/http", "inbound"},
+		{"internal/adapters/secondary/database/orders", "outbound"},
+		{"internal/config", "infrastructure"},
+		{"pkg/logger", "pkg"},
+		{"migrations", ""},
+	}
+
+	for _, tt := range tests {
+		if got := arch.layerOf(tt.dir); got != tt.want {
+			t.Errorf("layerOf(%s) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestHexagoRuleAllowsExternal(t *testing.T) {
+	rule := HexagoRule{
+		AllowExternal: []string{"std", "github.com/google/uuid"},
+		DenyExternal:  []string{"net/http", "os/exec"},
+	}
+
+	tests := []struct {
+		importPath string
+		want       bool
+	}{
+		{"context", true},
+		{"encoding/json", true},
+		{"net", true},
+		{"net/http", false},
+		{"net/http/httptest", false},
+		{"net/httpx", false},
+		{"os/exec", false},
+		{"github.com/google/uuid", true},
+		{"github.com/google/uuidx", false},
+		{"github.com/gin-gonic/gin", false},
+		{"gorm.io/gorm", false},
+		{"mycorp/lib", false},
+	}
+
+	std, err := analyzer.StandardPackages()
+	if err != nil {
+		t.Fatalf("StandardPackages() error = %v", err)
+	}
+	for _, tt := range tests {
+		if got := rule.allowsExternal(tt.importPath, std); got != tt.want {
+			t.Errorf("allowsExternal(%s) = %v, want %v", tt.importPath, got, tt.want)
+		}
+	}
+
+	if !(HexagoRule{MayImport: []string{"domain"}}).allowsExternal("gorm.io/gorm", std) {
+		t.Error("a rule without external lists must allow every external package")
+	}
+	if !(HexagoRule{DenyExternal: []string{"net/http"}}).allowsExternal("net/httpx", std) {
+		t.Error("net/http must not deny net/httpx")
+	}
+}
+
+// violationStrings returns the text output of violations
+func violationStrings(violations []Violation) []string {
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, v.String())
+	}
+	return lines
+}
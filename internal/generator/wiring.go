@@ -0,0 +1,320 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/padiazg/hexago/pkg/utils"
+)
+
+// WiringFile is the composition root hexago maintains in every project
+var WiringFile = filepath.Join("internal", "app", "wire_gen.go")
+
+// wiredRepository is a database adapter constructed by the composition root
+type wiredRepository struct {
+	Package     string // e.g. "categories"
+	Alias       string // e.g. "categoriesRepo"
+	Constructor string // e.g. "NewCategory"
+}
+
+// wiredService is an entity-bound service of the services aggregator
+type wiredService struct {
+	RepoField  string           // field of the services Config, e.g. "CategoriesRepository"
+	Repository *wiredRepository // nil when there is no database adapter for the entity
+	AdapterDir string           // where the database adapter is looked up
+}
+
+// wiredWorker is a worker registered with the worker manager
+type wiredWorker struct {
+	Constructor string   // e.g. "NewEmailWorker"
+	Args        []string // constants passed after the logger, e.g. "EmailWorkerInterval"
+}
+
+// wiringData is the data of the composition root template
+type wiringData struct {
+	*ProjectConfig
+	DBType      string
+	HasDatabase bool
+	HasGRPC     bool // the gRPC adapter, internal/adapters/<inbound>/grpc/grpc.go, exists
+	Services    []wiredService
+	Workers     []wiredWorker
+}
+
+// WiringGenerator maintains the composition root of a project,
+// internal/app/wire_gen.go: it constructs the repositories, the services
+// and their handlers and workers, and is regenerated from the components
+// found in the project whenever one is added or removed
+type WiringGenerator struct {
+	config *ProjectConfig
+}
+
+// NewWiringGenerator creates a new wiring generator
+func NewWiringGenerator(config *ProjectConfig) *WiringGenerator {
+	return &WiringGenerator{
+		config: config,
+	}
+}
+
+// Generate regenerates internal/app/wire_gen.go
+func (g *WiringGenerator) Generate() error {
+	return g.config.transact(g.generate)
+}
+
+// generate does the work of Generate inside its transaction
+func (g *WiringGenerator) generate() error {
+	if !g.config.hasWiring() {
+		return nil
+	}
+
+	content, err := g.render(".")
+	if err != nil {
+		return err
+	}
+	if current, err := g.config.readFile(WiringFile); err == nil && string(current) == string(content) {
+		return nil
+	}
+
+	fmt.Printf("📝 Updating composition root: %s\n", WiringFile)
+	return g.config.writeGenerated(WiringFile, "app/wire_gen.go.tmpl", content)
+}
+
+// render renders the composition root of the project in root
+func (g *WiringGenerator) render(root string) ([]byte, error) {
+	services, err := g.findServices(root)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := g.findWorkers(root)
+	if err != nil {
+		return nil, err
+	}
+
+	data := wiringData{
+		ProjectConfig: g.config,
+		DBType:        sqlDBType(g.config.DBDriver),
+		Services:      services,
+		Workers:       workers,
+	}
+	for _, s := range services {
+		data.HasDatabase = data.HasDatabase || s.Repository != nil
+	}
+	data.HasGRPC = g.config.fileExists(filepath.Join(root, "internal", "adapters", g.config.AdapterInboundDir(), "grpc", "grpc.go"))
+
+	content, err := g.config.templateLoader.Render("app/wire_gen.go.tmpl", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render composition root template: %w", err)
+	}
+	if formatted, err := format.Source(content); err == nil {
+		content = formatted
+	}
+	return content, nil
+}
+
+// findServices returns the entity-bound services of the aggregator, with
+// the database adapter of their entity
+func (g *WiringGenerator) findServices(root string) ([]wiredService, error) {
+	baseServiceDir := filepath.Join(root, "internal", "core", g.config.CoreLogicDir())
+	if !g.config.isDir(baseServiceDir) {
+		return nil, nil
+	}
+	entries, err := NewServiceGenerator(g.config).serviceEntries(baseServiceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseDir := filepath.Join("internal", "adapters", g.config.AdapterOutboundDir(), "database")
+	dbType := sqlDBType(g.config.DBDriver)
+
+	var services []wiredService
+	for _, entry := range entries {
+		if !entry.HasEntity {
+			continue
+		}
+		adapterDir := filepath.Join(databaseDir, entry.Package)
+		service := wiredService{RepoField: entry.RepoField, AdapterDir: adapterDir}
+		if constructor, ok := g.findConstructor(filepath.Join(root, adapterDir), dbType); ok {
+			service.Repository = &wiredRepository{
+				Package:     entry.Package,
+				Alias:       entry.Package + "Repo",
+				Constructor: constructor,
+			}
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// reportRepositoryWiring tells whether the composition root constructs the
+// database adapter in adapterDir: only the adapters storing the entity of a
+// service are wired, into that service
+func (c *ProjectConfig) reportRepositoryWiring(adapterDir string) error {
+	if !c.hasWiring() {
+		return nil
+	}
+	services, err := NewWiringGenerator(c).findServices(".")
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		if service.Repository != nil && service.AdapterDir == adapterDir {
+			fmt.Printf("🔌 %s.%s(db) is wired in %s\n", service.Repository.Package, service.Repository.Constructor, WiringFile)
+			return nil
+		}
+	}
+	fmt.Printf("⚠️  Warning: %s is not wired in %s: no service of the entity it stores uses it\n", adapterDir, WiringFile)
+	fmt.Printf("   Add one with: hexago add service <Name> --entity <Entity>\n")
+	return nil
+}
+
+// findConstructor returns the function of the package in dir constructing
+// an adapter from a database connection of type dbType
+func (g *WiringGenerator) findConstructor(dir, dbType string) (string, bool) {
+	files, err := g.parsePackage(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "New") {
+				continue
+			}
+			params := fn.Type.Params.List
+			if len(params) == 1 && len(params[0].Names) <= 1 && types.ExprString(params[0].Type) == dbType {
+				return fn.Name.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// findWorkers returns the workers of internal/workers. A worker is
+// constructed by New<Worker>(log logger.Logger, ...) and each parameter
+// after the logger is bound to the constant <Worker><Parameter>, e.g.
+// EmailWorkerInterval. Workers without these constants are skipped with a
+// warning.
+func (g *WiringGenerator) findWorkers(root string) ([]wiredWorker, error) {
+	workersDir := filepath.Join(root, "internal", "workers")
+	if !g.config.fileExists(filepath.Join(workersDir, "manager.go")) {
+		return nil, nil
+	}
+
+	files, err := g.parsePackage(workersDir)
+	if err != nil {
+		return nil, err
+	}
+
+	constants := make(map[string]bool)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.CONST {
+				for _, spec := range gen.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						constants[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	var workers []wiredWorker
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Name.Name == "NewManager" || !strings.HasPrefix(fn.Name.Name, "New") {
+				continue
+			}
+			params := fn.Type.Params.List
+			if len(params) == 0 || types.ExprString(params[0].Type) != "logger.Logger" || len(params[0].Names) > 1 {
+				continue
+			}
+
+			workerName := strings.TrimPrefix(fn.Name.Name, "New")
+			worker := wiredWorker{Constructor: fn.Name.Name}
+			for _, param := range params[1:] {
+				for _, name := range param.Names {
+					constant := workerName + utils.ToTitleCase(name.Name)
+					if !constants[constant] {
+						fmt.Printf("⚠️  Warning: worker %s not wired: constant %s not found\n", workerName, constant)
+						worker.Constructor = ""
+					}
+					worker.Args = append(worker.Args, constant)
+				}
+			}
+			if worker.Constructor != "" {
+				workers = append(workers, worker)
+			}
+		}
+	}
+	return workers, nil
+}
+
+// parsePackage parses the non-test Go files of dir, sorted by name
+func (g *WiringGenerator) parsePackage(dir string) ([]*ast.File, error) {
+	names, err := g.config.readDirNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		content, err := g.config.readFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, name, content, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, name), err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// hasWiring reports whether the project can have a composition root: it
+// constructs the services aggregator, which projects created before every
+// project type had one may lack
+func (c *ProjectConfig) hasWiring() bool {
+	return c.fileExists(filepath.Join("internal", "core", c.CoreLogicDir(), "services.go"))
+}
+
+// rewire regenerates the composition root after components were added or
+// removed
+func (c *ProjectConfig) rewire() error {
+	if err := NewWiringGenerator(c).generate(); err != nil {
+		return fmt.Errorf("failed to update %s: %w", WiringFile, err)
+	}
+	return nil
+}
+
+// durationExpr returns d as a Go expression, e.g. "5 * time.Minute"
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d", d)
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/padiazg/hexago/pkg/fsys"
+)
+
+func TestWiringGenerator(t *testing.T) {
+	mem := fsys.NewMem()
+	config := NewProjectConfig("demo", "example.com/demo")
+	config.FS = mem
+	config.InPlace = true
+
+	gen := NewProjectGenerator(config)
+	gen.projectPath = "."
+	if err := config.transact(gen.generateProject); err != nil {
+		t.Fatalf("generateProject() error = %v", err)
+	}
+	if got := string(mustRead(t, mem, WiringFile)); strings.Contains(got, "sql.Open") {
+		t.Errorf("database opened without a database adapter:\n%s", got)
+	}
+	if _, err := format.Source(mustRead(t, mem, WiringFile)); err != nil {
+		t.Errorf("%s is not valid Go: %v", WiringFile, err)
+	}
+
+	services := NewServiceGenerator(config)
+	for _, entity := range []string{"Category", "Product"} {
+		if err := services.Generate(entity, entity, "", nil); err != nil {
+			t.Fatalf("Generate(%s) error = %v", entity, err)
+		}
+	}
+	mustWrite(t, mem, "internal/adapters/secondary/database/categories/categories.go", `package categories
+
+import "database/sql"
+
+type Category struct{ db *sql.DB }
+
+func NewCategory(db *sql.DB) *Category {
+	return &Category{db: db}
+}
+`)
+
+	workers := NewWorkerGenerator(config)
+	if err := workers.Generate("Cleanup", WorkerConfig{Type: "periodic", Interval: "90s"}); err != nil {
+		t.Fatalf("Generate(Cleanup) error = %v", err)
+	}
+	if err := workers.Generate("Jobs", WorkerConfig{Type: "queue", Workers: 3, QueueSize: 10}); err != nil {
+		t.Fatalf("Generate(Jobs) error = %v", err)
+	}
+
+	wiring := string(mustRead(t, mem, WiringFile))
+	if _, err := format.Source([]byte(wiring)); err != nil {
+		t.Errorf("%s is not valid Go: %v", WiringFile, err)
+	}
+	for _, want := range []string{
+		`categoriesRepo "example.com/demo/internal/adapters/secondary/database/categories"`,
+		"db, err := sql.Open(cfg.Database.Driver, cfg.Database.URL)",
+		"CategoriesRepository: categoriesRepo.NewCategory(db),",
+		"// ProductsRepository: no database adapter in internal/adapters/secondary/database/products yet",
+		"a.workers.Register(workers.NewCleanup(log, workers.CleanupInterval))",
+		"a.workers.Register(workers.NewJobs(log, workers.JobsQueueSize, workers.JobsWorkers))",
+	} {
+		if !strings.Contains(wiring, want) {
+			t.Errorf("%s is missing %q:\n%s", WiringFile, want, wiring)
+		}
+	}
+	if got := string(mustRead(t, mem, "internal/workers/cleanup.go")); !strings.Contains(got, "const CleanupInterval = 90 * time.Second") {
+		t.Errorf("cleanup.go is missing its interval constant:\n%s", got)
+	}
+
+	if err := workers.Remove("Jobs"); err != nil {
+		t.Fatalf("Remove(Jobs) error = %v", err)
+	}
+	if got := string(mustRead(t, mem, WiringFile)); strings.Contains(got, "NewJobs") {
+		t.Errorf("removed worker still wired:\n%s", got)
+	}
+
+	mustWrite(t, mem, "internal/adapters/primary/grpc/grpc.go", "package grpc\n")
+	if err := NewWiringGenerator(config).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	wiring = string(mustRead(t, mem, WiringFile))
+	if _, err := format.Source([]byte(wiring)); err != nil {
+		t.Errorf("%s is not valid Go: %v", WiringFile, err)
+	}
+	for _, want := range []string{
+		`grpcadapter "example.com/demo/internal/adapters/primary/grpc"`,
+		"a.GRPCServer = grpcadapter.New(&grpcserver.ServerConfig{",
+		"a.GRPCServer.Run(errChan)",
+		"a.GRPCServer.Stop(ctx)",
+	} {
+		if !strings.Contains(wiring, want) {
+			t.Errorf("%s is missing %q:\n%s", WiringFile, want, wiring)
+		}
+	}
+
+	hexCfg, err := parseHexagoConfig(mustRead(t, mem, HexagoConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range hexCfg.Generated.Files {
+		if f.Path == WiringFile && f.Hash != hashContent(mustRead(t, mem, WiringFile)) {
+			t.Errorf("%s recorded with a stale hash", WiringFile)
+		}
+	}
+	if fsys.Exists(mem, ".hexago/base/"+WiringFile) {
+		t.Errorf("%s recorded with a base", WiringFile)
+	}
+}
+
+func TestDurationExpr(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{5 * time.Minute, "5 * time.Minute"},
+		{90 * time.Second, "90 * time.Second"},
+		{2 * time.Hour, "2 * time.Hour"},
+		{1500 * time.Millisecond, "1500 * time.Millisecond"},
+		{42, "42"},
+	}
+
+	for _, tt := range tests {
+		if got := durationExpr(tt.d); got != tt.want {
+			t.Errorf("durationExpr(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
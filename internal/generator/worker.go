@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/padiazg/hexago/pkg/utils"
 )
@@ -29,9 +30,16 @@ func NewWorkerGenerator(config *ProjectConfig) *WorkerGenerator {
 
 // Generate creates worker files
 func (g *WorkerGenerator) Generate(workerName string, workerConfig WorkerConfig) error {
+	return g.config.transact(func() error {
+		return g.generate(workerName, workerConfig)
+	})
+}
+
+// generate does the work of Generate inside its transaction
+func (g *WorkerGenerator) generate(workerName string, workerConfig WorkerConfig) error {
 	// Create workers directory if it doesn't exist
 	workersDir := filepath.Join("internal", "workers")
-	if err := utils.CreateDir(workersDir); err != nil {
+	if err := g.config.createDir(workersDir); err != nil {
 		return err
 	}
 
@@ -41,7 +49,7 @@ func (g *WorkerGenerator) Generate(workerName string, workerConfig WorkerConfig)
 	filePath := filepath.Join(workersDir, fileName)
 	testFilePath := filepath.Join(workersDir, testFileName)
 
-	if utils.FileExists(filePath) {
+	if g.config.fileExists(filePath) {
 		return fmt.Errorf("worker file %s already exists", filePath)
 	}
 
@@ -78,7 +86,7 @@ func (g *WorkerGenerator) Generate(workerName string, workerConfig WorkerConfig)
 		fmt.Printf("⚠️  Warning: failed to ensure worker manager: %v\n", err)
 	}
 
-	return nil
+	return g.config.rewire()
 }
 
 // generateQueueWorker generates a queue-based worker
@@ -95,15 +103,21 @@ func (g *WorkerGenerator) generateQueueWorker(filePath, workerName string, confi
 		return fmt.Errorf("failed to render queue worker template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "worker/queue.go.tmpl", content)
 }
 
 // generatePeriodicWorker generates a periodic worker
 func (g *WorkerGenerator) generatePeriodicWorker(filePath, workerName string, config WorkerConfig) error {
+	interval, err := time.ParseDuration(config.Interval)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("invalid interval '%s' for periodic worker", config.Interval)
+	}
+
 	data := map[string]any{
-		"ModuleName": g.config.ModuleName,
-		"WorkerName": workerName,
-		"Interval":   config.Interval,
+		"ModuleName":   g.config.ModuleName,
+		"WorkerName":   workerName,
+		"Interval":     config.Interval,
+		"IntervalExpr": durationExpr(interval),
 	}
 
 	content, err := g.config.templateLoader.Render("worker/periodic.go.tmpl", data)
@@ -111,7 +125,7 @@ func (g *WorkerGenerator) generatePeriodicWorker(filePath, workerName string, co
 		return fmt.Errorf("failed to render periodic worker template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "worker/periodic.go.tmpl", content)
 }
 
 // generateEventWorker generates an event-driven worker
@@ -119,6 +133,7 @@ func (g *WorkerGenerator) generateEventWorker(filePath, workerName string, confi
 	data := map[string]any{
 		"ModuleName": g.config.ModuleName,
 		"WorkerName": workerName,
+		"QueueSize":  config.QueueSize,
 	}
 
 	content, err := g.config.templateLoader.Render("worker/event.go.tmpl", data)
@@ -126,7 +141,7 @@ func (g *WorkerGenerator) generateEventWorker(filePath, workerName string, confi
 		return fmt.Errorf("failed to render event worker template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "worker/event.go.tmpl", content)
 }
 
 // generateWorkerTestFile generates test file for worker
@@ -141,7 +156,7 @@ func (g *WorkerGenerator) generateWorkerTestFile(filePath, workerName string) er
 		return fmt.Errorf("failed to render worker test template: %w", err)
 	}
 
-	return utils.WriteFile(filePath, content)
+	return g.config.writeGenerated(filePath, "worker/worker_test.go.tmpl", content)
 }
 
 // ensureWorkerManager creates or updates the worker manager
@@ -149,7 +164,7 @@ func (g *WorkerGenerator) ensureWorkerManager(workersDir string) error {
 	managerPath := filepath.Join(workersDir, "manager.go")
 
 	// If manager already exists, don't overwrite
-	if utils.FileExists(managerPath) {
+	if g.config.fileExists(managerPath) {
 		fmt.Printf("ℹ️  Worker manager already exists: %s\n", managerPath)
 		return nil
 	}
@@ -165,5 +180,5 @@ func (g *WorkerGenerator) ensureWorkerManager(workersDir string) error {
 		return fmt.Errorf("failed to render worker manager template: %w", err)
 	}
 
-	return utils.WriteFile(managerPath, content)
+	return g.config.writeGenerated(managerPath, "worker/manager.go.tmpl", content)
 }
@@ -0,0 +1,114 @@
+// Package fsys provides the filesystem abstraction used by the generators.
+//
+// Three implementations are available:
+//   - OS:      the real filesystem, with relative names resolved against a root
+//   - Mem:     an in-memory filesystem, useful for tests
+//   - Overlay: writes go to memory, reads fall through to a lower filesystem
+package fsys
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// FS is the set of filesystem operations the generators rely on.
+// Names use the host path separator; relative names are resolved by the
+// implementation (e.g. against the project root for OS).
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Remove(name string) error
+}
+
+// Exists checks if a file or directory exists
+func Exists(fsys FS, name string) bool {
+	_, err := fsys.Stat(name)
+	return err == nil
+}
+
+// IsDir checks if name is a directory
+func IsDir(fsys FS, name string) bool {
+	info, err := fsys.Stat(name)
+	return err == nil && info.IsDir()
+}
+
+// WriteFile writes content to a file, creating parent directories if needed
+func WriteFile(fsys FS, name string, content []byte) error {
+	dir := filepath.Dir(name)
+	if err := fsys.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	if err := fsys.WriteFile(name, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ReadDirNames returns the sorted entry names of a directory
+func ReadDirNames(fsys FS, name string) ([]string, error) {
+	entries, err := fsys.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// WalkDir walks the file tree rooted at root in lexical order, calling fn for
+// each file or directory. It mirrors io/fs.WalkDir, including SkipDir and SkipAll.
+func WalkDir(fsys FS, root string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDir(fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func walkDir(fsys FS, name string, d fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(name, d, nil); err != nil || !d.IsDir() {
+		if errors.Is(err, fs.SkipDir) && d.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := fsys.ReadDir(name)
+	if err != nil {
+		if err = fn(name, d, err); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				err = nil
+			}
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		if err := walkDir(fsys, filepath.Join(name, entry.Name()), entry, fn); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				break
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package fsys
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Mem is an in-memory filesystem. The zero value is not usable; use NewMem.
+type Mem struct {
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+// NewMem creates an empty in-memory filesystem
+func NewMem() *Mem {
+	return &Mem{
+		files: make(map[string][]byte),
+		dirs:  map[string]bool{".": true},
+	}
+}
+
+// Files returns the paths of all files, sorted
+func (m *Mem) Files() []string {
+	paths := make([]string, 0, len(m.files))
+	for name := range m.files {
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Dirs returns the paths of all directories except the root, sorted
+func (m *Mem) Dirs() []string {
+	paths := make([]string, 0, len(m.dirs))
+	for name := range m.dirs {
+		if name != "." {
+			paths = append(paths, name)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	data, ok := m.files[clean(name)]
+	if !ok {
+		return nil, pathError("open", name, fs.ErrNotExist)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = clean(name)
+	if m.dirs[name] {
+		return pathError("open", name, fs.ErrInvalid)
+	}
+	if !m.dirs[filepath.Dir(name)] {
+		return pathError("open", name, fs.ErrNotExist)
+	}
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *Mem) MkdirAll(path string, perm fs.FileMode) error {
+	for dir := clean(path); !m.dirs[dir]; dir = filepath.Dir(dir) {
+		if _, ok := m.files[dir]; ok {
+			return pathError("mkdir", dir, fs.ErrExist)
+		}
+		m.dirs[dir] = true
+	}
+	return nil
+}
+
+func (m *Mem) Stat(name string) (fs.FileInfo, error) {
+	name = clean(name)
+	if m.dirs[name] {
+		return memFileInfo{name: filepath.Base(name), dir: true}, nil
+	}
+	if data, ok := m.files[name]; ok {
+		return memFileInfo{name: filepath.Base(name), size: int64(len(data))}, nil
+	}
+	return nil, pathError("stat", name, fs.ErrNotExist)
+}
+
+func (m *Mem) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = clean(name)
+	if !m.dirs[name] {
+		return nil, pathError("readdir", name, fs.ErrNotExist)
+	}
+
+	var entries []fs.DirEntry
+	for dir := range m.dirs {
+		if dir != name && filepath.Dir(dir) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(memFileInfo{name: filepath.Base(dir), dir: true}))
+		}
+	}
+	for file, data := range m.files {
+		if filepath.Dir(file) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(memFileInfo{name: filepath.Base(file), size: int64(len(data))}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+func (m *Mem) Remove(name string) error {
+	name = clean(name)
+	if _, ok := m.files[name]; ok {
+		delete(m.files, name)
+		return nil
+	}
+	if !m.dirs[name] {
+		return pathError("remove", name, fs.ErrNotExist)
+	}
+
+	prefix := name + string(filepath.Separator)
+	for other := range m.dirs {
+		if strings.HasPrefix(other, prefix) {
+			return pathError("remove", name, fs.ErrExist)
+		}
+	}
+	for file := range m.files {
+		if strings.HasPrefix(file, prefix) {
+			return pathError("remove", name, fs.ErrExist)
+		}
+	}
+	delete(m.dirs, name)
+	return nil
+}
+
+// memFileInfo implements fs.FileInfo for Mem entries
+type memFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return i.dir }
+func (i memFileInfo) Sys() any           { return nil }
+
+func (i memFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+// clean normalizes a name so equivalent paths share the same key
+func clean(name string) string {
+	return filepath.Clean(name)
+}
+
+func pathError(op, name string, err error) error {
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
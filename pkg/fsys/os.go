@@ -0,0 +1,55 @@
+package fsys
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// OS is the real filesystem. Relative names are resolved against Root, so a
+// generator behaves the same regardless of the process working directory.
+type OS struct {
+	root string
+}
+
+// NewOS creates an OS filesystem rooted at root ("" means the current directory)
+func NewOS(root string) *OS {
+	return &OS{root: root}
+}
+
+// Root returns the directory relative names are resolved against
+func (o *OS) Root() string {
+	return o.root
+}
+
+// Abs returns the host path for name
+func (o *OS) Abs(name string) string {
+	if o.root == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(o.root, name)
+}
+
+func (o *OS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(o.Abs(name))
+}
+
+func (o *OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(o.Abs(name), data, perm)
+}
+
+func (o *OS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(o.Abs(path), perm)
+}
+
+func (o *OS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(o.Abs(name))
+}
+
+func (o *OS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(o.Abs(name))
+}
+
+func (o *OS) Remove(name string) error {
+	return os.Remove(o.Abs(name))
+}
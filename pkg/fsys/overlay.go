@@ -0,0 +1,163 @@
+package fsys
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// Overlay stacks an in-memory layer on top of a lower filesystem.
+// Writes and removals only affect the upper layer; reads see the upper layer
+// first and fall through to the lower one. The lower filesystem is never modified.
+type Overlay struct {
+	upper   *Mem
+	lower   FS
+	removed map[string]bool
+}
+
+// NewOverlay creates an overlay on top of lower
+func NewOverlay(lower FS) *Overlay {
+	return &Overlay{
+		upper:   NewMem(),
+		lower:   lower,
+		removed: make(map[string]bool),
+	}
+}
+
+// Lower returns the underlying filesystem
+func (o *Overlay) Lower() FS {
+	return o.lower
+}
+
+// Written returns the paths of all files written to the upper layer, sorted
+func (o *Overlay) Written() []string {
+	return o.upper.Files()
+}
+
+// Dirs returns the directories created in the upper layer, sorted
+func (o *Overlay) Dirs() []string {
+	return o.upper.Dirs()
+}
+
+// Removed returns the paths removed through the overlay, sorted
+func (o *Overlay) Removed() []string {
+	paths := make([]string, 0, len(o.removed))
+	for name := range o.removed {
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// isRemoved reports whether name or one of its parents was removed
+func (o *Overlay) isRemoved(name string) bool {
+	for dir := name; ; dir = filepath.Dir(dir) {
+		if o.removed[dir] {
+			return true
+		}
+		if dir == filepath.Dir(dir) {
+			return false
+		}
+	}
+}
+
+func (o *Overlay) ReadFile(name string) ([]byte, error) {
+	name = clean(name)
+	if data, err := o.upper.ReadFile(name); err == nil {
+		return data, nil
+	}
+	if o.isRemoved(name) {
+		return nil, pathError("open", name, fs.ErrNotExist)
+	}
+	return o.lower.ReadFile(name)
+}
+
+func (o *Overlay) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = clean(name)
+	if !IsDir(o, filepath.Dir(name)) {
+		return pathError("open", name, fs.ErrNotExist)
+	}
+	if err := o.upper.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	delete(o.removed, name)
+	return o.upper.WriteFile(name, data, perm)
+}
+
+func (o *Overlay) MkdirAll(path string, perm fs.FileMode) error {
+	path = clean(path)
+	for dir := path; ; dir = filepath.Dir(dir) {
+		delete(o.removed, dir)
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	return o.upper.MkdirAll(path, perm)
+}
+
+func (o *Overlay) Stat(name string) (fs.FileInfo, error) {
+	name = clean(name)
+	if info, err := o.upper.Stat(name); err == nil {
+		return info, nil
+	}
+	if o.isRemoved(name) {
+		return nil, pathError("stat", name, fs.ErrNotExist)
+	}
+	return o.lower.Stat(name)
+}
+
+func (o *Overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = clean(name)
+
+	upper, upperErr := o.upper.ReadDir(name)
+
+	var lower []fs.DirEntry
+	var lowerErr error = fs.ErrNotExist
+	if !o.isRemoved(name) {
+		lower, lowerErr = o.lower.ReadDir(name)
+	}
+	if upperErr != nil && lowerErr != nil {
+		if errors.Is(lowerErr, fs.ErrNotExist) {
+			return nil, pathError("readdir", name, fs.ErrNotExist)
+		}
+		return nil, lowerErr
+	}
+
+	merged := make(map[string]fs.DirEntry, len(upper)+len(lower))
+	for _, entry := range lower {
+		if !o.removed[filepath.Join(name, entry.Name())] {
+			merged[entry.Name()] = entry
+		}
+	}
+	for _, entry := range upper {
+		merged[entry.Name()] = entry
+	}
+
+	entries := make([]fs.DirEntry, 0, len(merged))
+	for _, entry := range merged {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+func (o *Overlay) Remove(name string) error {
+	name = clean(name)
+	if !Exists(o, name) {
+		return pathError("remove", name, fs.ErrNotExist)
+	}
+	if IsDir(o, name) {
+		if entries, _ := o.ReadDir(name); len(entries) > 0 {
+			return pathError("remove", name, fs.ErrExist)
+		}
+	}
+
+	// Drop the upper copy (if any) and hide the lower one
+	_ = o.upper.Remove(name)
+	if Exists(o.lower, name) {
+		o.removed[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package fsys
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	lower := NewMem()
+	if err := WriteFile(lower, "internal/core/services/services.go", []byte("package services\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(lower, "internal/core/services/categories/categories.go", []byte("package categories\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	overlay := NewOverlay(lower)
+	if err := WriteFile(overlay, "internal/core/services/products/products.go", []byte("package products\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(overlay, "internal/core/services/services.go", []byte("package services // updated\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := overlay.Remove("internal/core/services/categories/categories.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string // "" means the file must not exist
+	}{
+		{name: "read through to lower", path: "internal/core/services/services.go", want: "package services // updated\n"},
+		{name: "new file in upper", path: "internal/core/services/products/products.go", want: "package products\n"},
+		{name: "removed file", path: "internal/core/services/categories/categories.go", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := overlay.ReadFile(tt.path)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("ReadFile(%s) = %q, want not found", tt.path, got)
+				}
+				return
+			}
+			if err != nil || string(got) != tt.want {
+				t.Errorf("ReadFile(%s) = %q, %v, want %q", tt.path, got, err, tt.want)
+			}
+		})
+	}
+
+	names, err := ReadDirNames(overlay, "internal/core/services")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"categories", "products", "services.go"}; !slices.Equal(names, want) {
+		t.Errorf("ReadDirNames() = %v, want %v", names, want)
+	}
+
+	if data, _ := lower.ReadFile("internal/core/services/services.go"); string(data) != "package services\n" {
+		t.Errorf("lower filesystem was modified: %q", data)
+	}
+	if !Exists(lower, "internal/core/services/categories/categories.go") {
+		t.Error("lower filesystem file was removed")
+	}
+	if want := []string{"internal/core/services/products/products.go", "internal/core/services/services.go"}; !slices.Equal(overlay.Written(), want) {
+		t.Errorf("Written() = %v, want %v", overlay.Written(), want)
+	}
+}
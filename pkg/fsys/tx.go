@@ -0,0 +1,180 @@
+package fsys
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// Tx stages writes in an overlay and applies them to the lower filesystem
+// on Commit. Everything Commit created, overwrote or removed is recorded so
+// the transaction can be rolled back, leaving the lower filesystem as it was.
+type Tx struct {
+	*Overlay
+
+	committed   bool
+	createdDirs []string          // in creation order (parents first)
+	created     []string          // files that did not exist before Commit
+	overwritten map[string][]byte // original content of overwritten files
+	removed     map[string][]byte // original content of removed files
+	removedDirs []string          // removed directories (children first)
+}
+
+// Begin starts a transaction on top of lower
+func Begin(lower FS) *Tx {
+	return &Tx{
+		Overlay:     NewOverlay(lower),
+		overwritten: make(map[string][]byte),
+		removed:     make(map[string][]byte),
+	}
+}
+
+// Commit applies the staged changes to the lower filesystem. If any change
+// fails, the ones already applied are rolled back before returning.
+func (t *Tx) Commit() error {
+	if t.committed {
+		return fmt.Errorf("transaction already committed")
+	}
+	t.committed = true
+
+	if err := t.apply(); err != nil {
+		if rbErr := t.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback: %w", rbErr))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// apply writes directories, files and removals to the lower filesystem
+func (t *Tx) apply() error {
+	for _, dir := range t.Dirs() {
+		if err := t.mkdirAll(dir); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range t.Written() {
+		content, err := t.upper.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := t.mkdirAll(filepath.Dir(name)); err != nil {
+			return err
+		}
+
+		if old, err := t.lower.ReadFile(name); err == nil {
+			t.overwritten[name] = old
+		} else {
+			t.created = append(t.created, name)
+		}
+
+		if err := t.lower.WriteFile(name, content, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", name, err)
+		}
+	}
+
+	// Remove children before their parent directories
+	removed := t.Removed()
+	sort.Sort(sort.Reverse(sort.StringSlice(removed)))
+	for _, name := range removed {
+		if IsDir(t.lower, name) {
+			if err := t.lower.Remove(name); err != nil {
+				return fmt.Errorf("failed to remove directory %s: %w", name, err)
+			}
+			t.removedDirs = append(t.removedDirs, name)
+			continue
+		}
+
+		old, err := t.lower.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := t.lower.Remove(name); err != nil {
+			return fmt.Errorf("failed to remove file %s: %w", name, err)
+		}
+		t.removed[name] = old
+	}
+
+	return nil
+}
+
+// mkdirAll creates dir in the lower filesystem, recording every new level
+func (t *Tx) mkdirAll(dir string) error {
+	var missing []string
+	for d := clean(dir); !Exists(t.lower, d); d = filepath.Dir(d) {
+		missing = append(missing, d)
+		if d == filepath.Dir(d) {
+			break
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := t.lower.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		t.createdDirs = append(t.createdDirs, missing[i])
+	}
+	return nil
+}
+
+// Track records name as created by the transaction when it does not exist
+// yet, so files produced by later steps (e.g. go.mod) are removed on Rollback.
+func (t *Tx) Track(name string) {
+	if !Exists(t.lower, name) {
+		t.created = append(t.created, clean(name))
+	}
+}
+
+// Rollback undoes a committed transaction, or discards the staged changes
+// of one that was never committed.
+func (t *Tx) Rollback() error {
+	if !t.committed {
+		t.Overlay = NewOverlay(t.lower)
+		return nil
+	}
+
+	var errs []error
+
+	// Restore removed directories and files
+	for i := len(t.removedDirs) - 1; i >= 0; i-- {
+		if err := t.lower.MkdirAll(t.removedDirs[i], 0755); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for name, content := range t.removed {
+		if err := WriteFile(t.lower, name, content); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Restore overwritten files
+	for name, content := range t.overwritten {
+		if err := t.lower.WriteFile(name, content, 0644); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Delete created files, then created directories (children first)
+	for i := len(t.created) - 1; i >= 0; i-- {
+		if err := t.lower.Remove(t.created[i]); err != nil && Exists(t.lower, t.created[i]) {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(t.createdDirs) - 1; i >= 0; i-- {
+		if err := t.lower.Remove(t.createdDirs[i]); err != nil && Exists(t.lower, t.createdDirs[i]) {
+			errs = append(errs, err)
+		}
+	}
+
+	t.created, t.createdDirs, t.removedDirs = nil, nil, nil
+	t.overwritten = make(map[string][]byte)
+	t.removed = make(map[string][]byte)
+
+	return errors.Join(errs...)
+}
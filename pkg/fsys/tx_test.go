@@ -0,0 +1,82 @@
+package fsys
+
+import "testing"
+
+func TestTx(t *testing.T) {
+	newLower := func(t *testing.T) *Mem {
+		lower := NewMem()
+		if err := WriteFile(lower, "internal/core/services/services.go", []byte("package services\n")); err != nil {
+			t.Fatal(err)
+		}
+		return lower
+	}
+
+	stage := func(t *testing.T, tx *Tx) {
+		if err := WriteFile(tx, "internal/core/services/products/products.go", []byte("package products\n")); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFile(tx, "internal/core/services/services.go", []byte("package services // updated\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("commit applies staged changes", func(t *testing.T) {
+		lower := newLower(t)
+		tx := Begin(lower)
+		stage(t, tx)
+
+		if Exists(lower, "internal/core/services/products/products.go") {
+			t.Fatal("staged file written before commit")
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := lower.ReadFile("internal/core/services/services.go")
+		if err != nil || string(got) != "package services // updated\n" {
+			t.Errorf("services.go = %q, %v", got, err)
+		}
+		if !Exists(lower, "internal/core/services/products/products.go") {
+			t.Error("products.go not committed")
+		}
+	})
+
+	t.Run("rollback restores lower", func(t *testing.T) {
+		lower := newLower(t)
+		tx := Begin(lower)
+		stage(t, tx)
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := lower.ReadFile("internal/core/services/services.go")
+		if err != nil || string(got) != "package services\n" {
+			t.Errorf("services.go = %q, %v", got, err)
+		}
+		if Exists(lower, "internal/core/services/products") {
+			t.Error("created directory not removed")
+		}
+	})
+
+	t.Run("rollback tracked file", func(t *testing.T) {
+		lower := newLower(t)
+		tx := Begin(lower)
+		stage(t, tx)
+		tx.Track("go.mod")
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFile(lower, "go.mod", []byte("module demo\n")); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+		if Exists(lower, "go.mod") {
+			t.Error("tracked file not removed")
+		}
+	})
+}
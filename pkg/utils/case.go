@@ -16,6 +16,24 @@ func ToPlural(s string) string {
 	}
 }
 
+// ToSingular reverses ToPlural (sufficient for typical resource names).
+func ToSingular(s string) string {
+	s = strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(s, "ies") && len(s) > 3:
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "ses") || strings.HasSuffix(s, "xes") ||
+		strings.HasSuffix(s, "ches") || strings.HasSuffix(s, "shes"):
+		return s[:len(s)-2]
+	case strings.HasSuffix(s, "ss"):
+		return s
+	case strings.HasSuffix(s, "s"):
+		return s[:len(s)-1]
+	default:
+		return s
+	}
+}
+
 // toSnakeCase converts PascalCase to snake_case
 func ToSnakeCase(s string) string {
 	var result strings.Builder
@@ -28,6 +46,11 @@ func ToSnakeCase(s string) string {
 	return strings.ToLower(result.String())
 }
 
+// ToKebabCase converts PascalCase to kebab-case
+func ToKebabCase(s string) string {
+	return strings.ReplaceAll(ToSnakeCase(s), "_", "-")
+}
+
 // toTitleCase converts a string to title case (simple implementation)
 func ToTitleCase(s string) string {
 	if len(s) == 0 {
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffOp is a single line-level edit produced by diffLines
+type diffOp struct {
+	kind byte // ' ' (equal), '-' (delete) or '+' (insert)
+	line string
+	a, b int // line index in the old and new text
+}
+
+// SplitLines splits text into lines, keeping a trailing newline out of the result
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes the line-level edit script turning a into b (LCS based)
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i], a: i, b: j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', line: a[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j], a: i, b: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{kind: '-', line: a[i], a: i, b: j})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{kind: '+', line: b[j], a: i, b: j})
+	}
+
+	return ops
+}
+
+// UnifiedDiff returns a unified diff between oldText and newText.
+// An empty string is returned when both texts are identical.
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := diffLines(SplitLines(oldText), SplitLines(newText))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(ops); {
+		// Skip to the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+		hunk := ops[from:to]
+
+		var oldCount, newCount int
+		for _, op := range hunk {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(hunk[0].a, oldCount), hunkRange(hunk[0].b, newCount))
+		for _, op := range hunk {
+			fmt.Fprintf(&out, "%c%s\n", op.kind, op.line)
+		}
+
+		start = to
+	}
+
+	return out.String()
+}
+
+// hunkRange formats a unified diff range ("start,count")
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
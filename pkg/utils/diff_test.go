@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    string
+	}{
+		{
+			name:    "identical",
+			oldText: "a\nb\n",
+			newText: "a\nb\n",
+			want:    "",
+		},
+		{
+			name:    "new file",
+			oldText: "",
+			newText: "a\nb\n",
+			want:    "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:    "insert in the middle",
+			oldText: "a\nb\nc\nd\n",
+			newText: "a\nb\nx\nc\nd\n",
+			want:    "--- old\n+++ new\n@@ -1,4 +1,5 @@\n a\n b\n+x\n c\n d\n",
+		},
+		{
+			name:    "separate hunks",
+			oldText: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			newText: "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want: "--- old\n+++ new\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("old", "new", tt.oldText, tt.newText)
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+)
+
+// MergeLabels names the three sides of a merge in conflict markers
+type MergeLabels struct {
+	Ours   string
+	Base   string
+	Theirs string
+}
+
+// Merge3 merges the changes from base to ours and from base to theirs, line
+// by line like diff3. Regions changed differently on both sides are kept as
+// conflicts between git style markers, with the base text in between. It
+// returns the merged text and the number of conflicts.
+func Merge3(base, ours, theirs string, labels MergeLabels) (string, int) {
+	b, o, t := SplitLines(base), SplitLines(ours), SplitLines(theirs)
+	oursMatch := matchLines(b, o)
+	theirsMatch := matchLines(b, t)
+
+	var out []string
+	conflicts := 0
+	i, j, k := 0, 0, 0
+	for i < len(b) || j < len(o) || k < len(t) {
+		// All three sides agree on the line
+		if i < len(b) && oursMatch[i] == j && theirsMatch[i] == k {
+			out = append(out, b[i])
+			i, j, k = i+1, j+1, k+1
+			continue
+		}
+
+		// The next base line kept by both sides ends the changed region
+		i2, j2, k2 := len(b), len(o), len(t)
+		for n := i; n < len(b); n++ {
+			if oursMatch[n] >= 0 && theirsMatch[n] >= 0 {
+				i2, j2, k2 = n, oursMatch[n], theirsMatch[n]
+				break
+			}
+		}
+
+		baseChunk, oursChunk, theirsChunk := b[i:i2], o[j:j2], t[k:k2]
+		switch {
+		case slices.Equal(oursChunk, baseChunk), slices.Equal(oursChunk, theirsChunk):
+			out = append(out, theirsChunk...)
+		case slices.Equal(theirsChunk, baseChunk):
+			out = append(out, oursChunk...)
+		default:
+			conflicts++
+			out = append(out, "<<<<<<< "+labels.Ours)
+			out = append(out, oursChunk...)
+			out = append(out, "||||||| "+labels.Base)
+			out = append(out, baseChunk...)
+			out = append(out, "=======")
+			out = append(out, theirsChunk...)
+			out = append(out, ">>>>>>> "+labels.Theirs)
+		}
+		i, j, k = i2, j2, k2
+	}
+
+	if len(out) == 0 {
+		return "", conflicts
+	}
+	return strings.Join(out, "\n") + "\n", conflicts
+}
+
+// CommonLines returns the lines a and b have in common, in order. As the base
+// of Merge3 when the real one is unknown, it makes every line only one side
+// has an addition of that side.
+func CommonLines(a, b string) string {
+	var out []string
+	for _, op := range diffLines(SplitLines(a), SplitLines(b)) {
+		if op.kind == ' ' {
+			out = append(out, op.line)
+		}
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// matchLines maps each line of a to the index of the same line in b, or -1
+// when the line was changed or deleted
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, op := range diffLines(a, b) {
+		if op.kind == ' ' {
+			match[op.a] = op.b
+		}
+	}
+	return match
+}
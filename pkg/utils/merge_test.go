@@ -0,0 +1,89 @@
+package utils
+
+import "testing"
+
+func TestCommonLines(t *testing.T) {
+	if got := CommonLines("a\nb\nc\nd\n", "a\nx\nc\nd\ny\n"); got != "a\nc\nd\n" {
+		t.Errorf("CommonLines() = %q, want %q", got, "a\nc\nd\n")
+	}
+	if got := CommonLines("a\n", "b\n"); got != "" {
+		t.Errorf("CommonLines() = %q, want empty", got)
+	}
+}
+
+func TestMerge3(t *testing.T) {
+	labels := MergeLabels{Ours: "yours", Base: "base", Theirs: "new"}
+
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts int
+	}{
+		{
+			name:   "only theirs changed",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nB\nc\n",
+			want:   "a\nB\nc\n",
+		},
+		{
+			name:   "only ours changed",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\nd\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nb\nc\nd\n",
+		},
+		{
+			name:   "separate changes",
+			base:   "a\nb\nc\nd\ne\n",
+			ours:   "a\nuser\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\nE\n",
+			want:   "a\nuser\nb\nc\nd\nE\n",
+		},
+		{
+			name:   "same change on both sides",
+			base:   "a\nb\nc\n",
+			ours:   "a\nx\nc\n",
+			theirs: "a\nx\nc\n",
+			want:   "a\nx\nc\n",
+		},
+		{
+			name:   "deletion and insertion",
+			base:   "a\nb\nc\n",
+			ours:   "a\nc\n",
+			theirs: "a\nb\nc\nd\n",
+			want:   "a\nc\nd\n",
+		},
+		{
+			name:      "conflict",
+			base:      "a\nb\nc\n",
+			ours:      "a\nmine\nc\n",
+			theirs:    "a\nnew\nc\n",
+			want:      "a\n<<<<<<< yours\nmine\n||||||| base\nb\n=======\nnew\n>>>>>>> new\nc\n",
+			conflicts: 1,
+		},
+		{
+			name:      "no base",
+			base:      "",
+			ours:      "a\nmine\nc\n",
+			theirs:    "a\nnew\nc\n",
+			want:      "<<<<<<< yours\na\nmine\nc\n||||||| base\n=======\na\nnew\nc\n>>>>>>> new\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := Merge3(tt.base, tt.ours, tt.theirs, labels)
+			if got != tt.want {
+				t.Errorf("Merge3() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if conflicts != tt.conflicts {
+				t.Errorf("Merge3() conflicts = %d, want %d", conflicts, tt.conflicts)
+			}
+		})
+	}
+}